@@ -8,10 +8,14 @@ import (
     "binance-trading-bot/internal/strategy"
     "binance-trading-bot/internal/telegram"
     "binance-trading-bot/pkg/types"
+    "errors"
     "fmt"
     "log"
+    "math"
     "os"
+    "sort"
     "strings"
+    "sync"
     "time"
     
     "github.com/joho/godotenv"
@@ -26,7 +30,8 @@ type Bot struct {
     config         *types.Config
     positions      []types.Position
     lastReportTime time.Time
-    alertedCoins   map[string]time.Time // Track when we last alerted for each coin
+    alertedCoins   map[string]time.Time      // Track when we last alerted for each coin
+    confirmer      *strategy.SignalConfirmer // nil when confirmation is disabled
     startTime      time.Time
 }
 
@@ -66,17 +71,38 @@ func NewBot(configPath string) (*Bot, error) {
         config.Binance.APIKey,
         config.Binance.SecretKey,
         config.Binance.Testnet,
+        binance.ClientOptions{
+            WeightPerMinute: config.Binance.RequestWeightPerMinute,
+            KlineCacheTTL:   time.Duration(config.Binance.KlineCacheTTLSeconds) * time.Second,
+        },
     )
     
-    strat := strategy.NewMomentumStrategy(&config, client)
+    strat, err := strategy.NewMomentumStrategy(&config, client)
+    if err != nil {
+        return nil, err
+    }
     
     balances, err := client.GetAccountBalance()
     if err != nil {
         log.Printf("Warning: Could not get balance: %v", err)
     }
     
+    var confirmer *strategy.SignalConfirmer
+    if config.Strategy.Confirmation.Enabled {
+        confirmer, err = strategy.NewSignalConfirmer(&config)
+        if err != nil {
+            return nil, err
+        }
+    }
+    
+    switch config.Risk.PositionSizing.Mode {
+    case "", "dynamic", "risk_percent", "kelly":
+    default:
+        return nil, fmt.Errorf("unknown position sizing mode %q (expected dynamic, risk_percent or kelly)", config.Risk.PositionSizing.Mode)
+    }
+    
     initialBalance := balances["USDT"]
-    riskMgr := risk.NewManager(&config, initialBalance)
+    riskMgr := risk.NewManager(&config, initialBalance, client)
     
     notifier := telegram.NewNotifier(
         config.Telegram.BotToken,
@@ -90,9 +116,10 @@ func NewBot(configPath string) (*Bot, error) {
         risk:           riskMgr,
         telegram:       notifier,
         config:         &config,
-        positions:      make([]types.Position, 0),
+        positions:      riskMgr.LoadSetups(),
         lastReportTime: time.Now(),
         alertedCoins:   make(map[string]time.Time),
+        confirmer:      confirmer,
         startTime:      time.Now(),
     }, nil
 }
@@ -136,6 +163,11 @@ func (b *Bot) Run() {
 }
 
 func (b *Bot) mainLoop() {
+    // Stops, targets and strategy exits for every open position
+    if len(b.positions) > 0 {
+        b.updatePositions()
+    }
+    
     tickers, err := b.client.Get24hrTickers()
     if err != nil {
         log.Printf("❌ Error fetching tickers: %v", err)
@@ -145,6 +177,8 @@ func (b *Bot) mainLoop() {
     
     log.Printf("📡 Scanned %d total tickers", len(tickers))
     
+    b.updateEquity(tickers)
+    
     // Count USDT pairs
     usdtPairs := 0
     for _, t := range tickers {
@@ -154,6 +188,11 @@ func (b *Bot) mainLoop() {
     }
     log.Printf("💱 Found %d USDT pairs", usdtPairs)
     
+    // Market-wide state shared by the strategy and the risk manager
+    market := b.strategy.UpdateMarketState(tickers)
+    b.risk.SetMarketState(market)
+    log.Printf("🌍 Market: %s", strategy.DescribeMarketState(market))
+    
     hotCoins := b.strategy.FindHotCoins(tickers)
     log.Printf("🔥 Hot coins after filtering: %d", len(hotCoins))
     
@@ -165,7 +204,7 @@ func (b *Bot) mainLoop() {
             if i < 10 {
                 log.Printf("  %d. %s: +%.2f%% | Volume: $%.0f | Price: $%.4f",
                     i+1, coin.Symbol, coin.PriceChangePercent, 
-                    coin.QuoteVolume, coin.LastPrice)
+                    coin.NotionalVolume, coin.LastPrice)
                 if i < 5 {
                     hotCoinSummary = append(hotCoinSummary, 
                         fmt.Sprintf("%s: +%.2f%%", coin.Symbol, coin.PriceChangePercent))
@@ -182,18 +221,37 @@ func (b *Bot) mainLoop() {
         log.Printf("   - Min Price Change: %.1f%%", b.config.Strategy.MinPriceChange)
     }
     
-    b.analyzeAndAlert(hotCoins)
+    // New listings lack the history GenerateSignal needs - they get their own path
+    candidates := hotCoins
+    if b.config.Strategy.NewListings.Enabled {
+        for _, listing := range b.strategy.DetectNewListings(tickers) {
+            b.telegram.NotifyNewListing(listing.Symbol, listing.Ticker.QuoteAsset, 
+                listing.Ticker.LastPrice, listing.Ticker.QuoteVolume, listing.Source)
+        }
+        listings := b.strategy.ListingCandidates(tickers, hotCoins)
+        if len(listings) > 0 {
+            log.Printf("🆕 Analyzing %d new listings", len(listings))
+        }
+        candidates = append(candidates, listings...)
+    }
+    
+    b.analyzeAndAlert(candidates)
     b.displayStatus(len(hotCoins))
 }
 
 func (b *Bot) analyzeAndAlert(hotCoins []types.Ticker) {
-    canOpen, reason := b.risk.CanOpenPosition(b.positions)
+    canOpen, _, reason := b.risk.CanOpenPosition("", b.positions)
     
     if !canOpen {
         log.Printf("⚠️  Cannot open new positions: %s", reason)
+        if b.confirmer != nil {
+            b.confirmer.Reset() // Evaluations are no longer consecutive
+        }
         return
     }
     
+    candidates := make([]types.Ticker, 0, len(hotCoins))
+    tickers := make(map[string]types.Ticker, len(hotCoins))
     for _, coin := range hotCoins {
         // Skip if we recently alerted about this coin (within last 10 minutes)
         if lastAlert, exists := b.alertedCoins[coin.Symbol]; exists {
@@ -203,43 +261,220 @@ func (b *Bot) analyzeAndAlert(hotCoins []types.Ticker) {
                 continue
             }
         }
-        
-        log.Printf("\n🔍 Analyzing %s...", coin.Symbol)
-        
-        signal := b.strategy.GenerateSignal(coin, b.positions)
-        
+        candidates = append(candidates, coin)
+        tickers[coin.Symbol] = coin
+    }
+    
+    signals := b.analyzeCandidates(candidates)
+    if b.confirmer != nil {
+        signals = b.confirmer.Confirm(signals)
+    }
+    
+    for _, signal := range signals {
         // Log detailed analysis
-        log.Printf("   Signal: %s | Strength: %.2f | MTF Score: %.2f", 
-            signal.Action, signal.Strength, signal.MTFScore)
+        log.Printf("\n📋 %s: %s | Strength: %.2f | MTF Score: %.2f", 
+            signal.Symbol, signal.Action, signal.Strength, signal.MTFScore)
         log.Printf("   Reason: %s", signal.Reason)
+    }
+    
+    // Every BUY already cleared its threshold - alert the best ones
+    ranked := b.rankSignals(signals, tickers)
+    if len(ranked) == 0 {
+        return
+    }
+    
+    limit := b.config.Strategy.SignalRanking.MaxAlertsPerCycle
+    if limit <= 0 {
+        limit = 1
+    }
+    if maxPositions := b.config.Strategy.MaxPositions; maxPositions > 0 && maxPositions-len(b.positions) < limit {
+        limit = maxPositions - len(b.positions)
+    }
+    
+    log.Printf("\n🏆 %d BUY signals ranked, alerting up to %d:", len(ranked), limit)
+    for i, r := range ranked {
+        log.Printf("   %d. %s: score %.2f | strength %.0f%% | R/R 1:%.2f | volume $%.0f", 
+            i+1, r.signal.Symbol, r.score, r.signal.Strength*100, r.riskReward, r.liquidity)
+    }
+    
+    alerted := 0
+    for _, r := range ranked {
+        if alerted >= limit {
+            log.Printf("   ⏭️  %s not alerted - cycle limit of %d reached", r.signal.Symbol, limit)
+            continue
+        }
         
-        // Send alert if BUY signal with good strength
-        if signal.Action == "BUY" && signal.Strength > 0.3 {
-            b.sendTradeAlert(signal)
-            b.alertedCoins[coin.Symbol] = time.Now()
-            
-            // Only alert for one coin per cycle to avoid spam
-            break
-        } else if signal.Action == "BUY" {
-            log.Printf("   ⚠️  Signal strength too low (%.2f < 0.3)", signal.Strength)
+        // Skip or downsize coins that move with the open positions and the
+        // recent alerts, including those sent earlier this cycle
+        allowed, sizeMultiplier, correlationNote := b.risk.CanOpenPosition(r.signal.Symbol, b.positions)
+        if correlationNote != "" {
+            log.Printf("   🔗 %s", correlationNote)
+        }
+        if !allowed {
+            log.Printf("   ⚠️  Skipping %s: %s", r.signal.Symbol, correlationNote)
+            continue
+        }
+        
+        if !b.sendTradeAlert(r.signal, sizeMultiplier) {
+            continue
         }
+        b.risk.RecordAlert(r.signal.Symbol)
+        b.alertedCoins[r.signal.Symbol] = time.Now()
+        alerted++
     }
 }
 
-func (b *Bot) sendTradeAlert(signal types.Signal) {
+// rankedSignal is a BUY signal with the measures it was ranked on
+type rankedSignal struct {
+    signal     types.Signal
+    riskReward float64
+    liquidity  float64 // 24h volume in the notional asset
+    score      float64
+}
+
+// rankSignals orders the BUY signals by a weighted mix of strength,
+// risk/reward (relative to the target ratio) and liquidity (percentile of
+// 24h volume among the BUY signals), best first
+func (b *Bot) rankSignals(signals []types.Signal, tickers map[string]types.Ticker) []rankedSignal {
+    cfg := b.config.Strategy.SignalRanking
+    strengthWeight, rrWeight, liquidityWeight := cfg.StrengthWeight, cfg.RiskRewardWeight, cfg.LiquidityWeight
+    if strengthWeight+rrWeight+liquidityWeight <= 0 {
+        strengthWeight, rrWeight, liquidityWeight = 0.6, 0.25, 0.15
+    }
+    targetRR := cfg.TargetRiskReward
+    if targetRR <= 0 {
+        targetRR = 3.0
+    }
+    
+    ranked := []rankedSignal{}
+    for _, signal := range signals {
+        if signal.Action != "BUY" {
+            continue
+        }
+        stopLoss := b.risk.CalculateStopLossWithLevels(signal.Price, "BUY", signal.ATR, signal.Levels)
+        takeProfit := b.risk.CalculateTakeProfitWithLevels(signal.Price, "BUY", signal.Strength, signal.Levels)
+        rr, _ := b.risk.AnalyzeRiskReward(signal.Price, stopLoss, takeProfit)
+        ranked = append(ranked, rankedSignal{
+            signal:     signal,
+            riskReward: rr,
+            liquidity:  strategy.NotionalVolume(tickers[signal.Symbol]),
+        })
+    }
+    
+    for i := range ranked {
+        liquidity := 1.0
+        if len(ranked) > 1 {
+            below := 0
+            for j := range ranked {
+                if ranked[j].liquidity < ranked[i].liquidity {
+                    below++
+                }
+            }
+            liquidity = float64(below) / float64(len(ranked)-1)
+        }
+        rrScore := math.Min(ranked[i].riskReward/targetRR, 1)
+        ranked[i].score = (strengthWeight*ranked[i].signal.Strength + rrWeight*rrScore + liquidityWeight*liquidity) / 
+            (strengthWeight + rrWeight + liquidityWeight)
+    }
+    
+    sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+    return ranked
+}
+
+// analyzeCandidates generates signals with a bounded pool of workers. Kline
+// requests go through the client's rate limiter and shared cache. Signals are
+// returned in candidate order.
+func (b *Bot) analyzeCandidates(candidates []types.Ticker) []types.Signal {
+    workers := b.config.Strategy.AnalysisWorkers
+    if workers <= 0 {
+        workers = 4
+    }
+    if workers > len(candidates) {
+        workers = len(candidates)
+    }
+    
+    start := time.Now()
+    signals := make([]types.Signal, len(candidates))
+    jobs := make(chan int)
+    var wg sync.WaitGroup
+    
+    for w := 0; w < workers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := range jobs {
+                log.Printf("\n🔍 Analyzing %s...", candidates[i].Symbol)
+                if b.config.Strategy.NewListings.Enabled && b.strategy.IsNewListing(candidates[i].Symbol) {
+                    signals[i] = b.strategy.AnalyzeNewListing(candidates[i])
+                    continue
+                }
+                signals[i] = b.strategy.GenerateSignal(candidates[i], b.positions)
+            }
+        }()
+    }
+    
+    for i := range candidates {
+        jobs <- i
+    }
+    close(jobs)
+    wg.Wait()
+    
+    hits, misses := b.client.KlineCacheStats()
+    log.Printf("\n⏱️  Analyzed %d coins in %.1fs with %d workers (kline cache: %d hits, %d fetches)",
+        len(candidates), time.Since(start).Seconds(), workers, hits, misses)
+    
+    return signals
+}
+
+// sendTradeAlert logs and sends the suggested setup for a BUY signal. Returns
+// false when no valid position size could be derived and nothing was sent.
+func (b *Bot) sendTradeAlert(signal types.Signal, sizeMultiplier float64) bool {
+    // Size in the notional asset for pairs quoted in BTC, FDUSD, ...
+    notionalRate := signal.NotionalRate
+    if notionalRate <= 0 {
+        notionalRate = 1
+    }
+    
+    // NEW: Use dynamic position sizing and stop loss
+    volatility := (signal.ATR / signal.Price) * 100  // ATR as percentage
+    stopLoss := b.risk.CalculateStopLossWithLevels(signal.Price, "BUY", signal.ATR, signal.Levels)
+    takeProfit := b.risk.CalculateTakeProfitWithLevels(signal.Price, "BUY", signal.Strength, signal.Levels)
+    
+    var quantity float64
+    var riskSize *risk.RiskSize
+    switch b.config.Risk.PositionSizing.Mode {
+    case "risk_percent":
+        size, err := b.riskSize(signal, stopLoss, sizeMultiplier)
+        if err != nil {
+            log.Printf("   ⚠️  Risk sizing failed for %s: %v - using dynamic sizing", signal.Symbol, err)
+        } else {
+            quantity, riskSize = size.Quantity, &size
+        }
+    case "kelly":
+        size, err := b.kellySize(signal, stopLoss, sizeMultiplier)
+        switch {
+        case errors.Is(err, risk.ErrKellyNoEdge):
+            log.Printf("   ⚠️  Skipping %s: %v", signal.Symbol, err)
+            return false
+        case errors.Is(err, risk.ErrKellySamples):
+            log.Printf("   📐 %v - using dynamic sizing", err)
+        case err != nil:
+            log.Printf("   ⚠️  Kelly sizing failed for %s: %v - using dynamic sizing", signal.Symbol, err)
+        default:
+            quantity, riskSize = size.Quantity, &size
+        }
+    }
+    if riskSize == nil {
+        quantity = b.risk.CalculatePositionSize(signal.Price*notionalRate, signal.Strength, volatility) * sizeMultiplier
+    }
+    
     log.Printf("\n🚨 TRADE ALERT - MANUAL ACTION REQUIRED 🚨")
     log.Printf("📊 BUY SIGNAL: %s at $%.4f", signal.Symbol, signal.Price)
     log.Printf("   Strength: %.2f | MTF Score: %.2f", signal.Strength, signal.MTFScore)
     log.Printf("   Reason: %s", signal.Reason)
     
-    // NEW: Use dynamic position sizing and stop loss
-    volatility := (signal.ATR / signal.Price) * 100  // ATR as percentage
-    quantity := b.risk.CalculatePositionSize(signal.Price, signal.Strength, volatility)
-    stopLoss := b.risk.CalculateStopLoss(signal.Price, "BUY", signal.ATR)
-    takeProfit := b.risk.CalculateTakeProfit(signal.Price, "BUY", signal.Strength)
-    
     // Calculate actual position size in USDT
-    actualPositionSize := quantity * signal.Price
+    actualPositionSize := quantity * signal.Price * notionalRate
     
     // Calculate stop loss and take profit percentages
     stopLossPercent := ((signal.Price - stopLoss) / signal.Price) * 100
@@ -252,10 +487,27 @@ func (b *Bot) sendTradeAlert(signal types.Signal) {
     log.Printf("   Symbol: %s", signal.Symbol)
     log.Printf("   Entry: $%.4f", signal.Price)
     log.Printf("   Quantity: %.4f (≈ $%.2f)", quantity, actualPositionSize)
-    log.Printf("   Position Size: %.0f%% of base (Signal: %.0f%%, Volatility: %.1f%%)", 
-        (actualPositionSize/b.config.Strategy.PositionSize)*100, 
-        signal.Strength*100, 
-        volatility)
+    if riskSize != nil && b.config.Risk.PositionSizing.Mode == "kelly" {
+        log.Printf("   Position Size: %.2f%% of $%.2f equity (Kelly), $%.2f at risk over a %.2f%% stop", 
+            actualPositionSize/riskSize.Equity*100, riskSize.Equity, riskSize.RiskAmount, riskSize.StopDistance)
+        if len(riskSize.Caps) > 0 {
+            log.Printf("   Size capped by: %s", strings.Join(riskSize.Caps, ", "))
+        }
+    } else if riskSize != nil {
+        log.Printf("   Risk: $%.2f (%.2f%% of $%.2f equity) over a %.2f%% stop", 
+            riskSize.RiskAmount, riskSize.RiskAmount/riskSize.Equity*100, riskSize.Equity, riskSize.StopDistance)
+        if len(riskSize.Caps) > 0 {
+            log.Printf("   Size capped by: %s", strings.Join(riskSize.Caps, ", "))
+        }
+    } else {
+        log.Printf("   Position Size: %.0f%% of base (Signal: %.0f%%, Volatility: %.1f%%)", 
+            (actualPositionSize/b.config.Strategy.PositionSize)*100, 
+            signal.Strength*100, 
+            volatility)
+    }
+    if sizeMultiplier < 1 {
+        log.Printf("   Correlation: size reduced to %.0f%% (moves with open positions or recent alerts)", sizeMultiplier*100)
+    }
     log.Printf("   Stop Loss: $%.4f (%.2f%%)", stopLoss, stopLossPercent)
     log.Printf("   Take Profit: $%.4f (%.2f%%)", takeProfit, takeProfitPercent)
     log.Printf("   Risk/Reward: 1:%.2f %s", rrRatio, map[bool]string{true: "✅", false: "⚠️"}[acceptable])
@@ -285,9 +537,146 @@ func (b *Bot) sendTradeAlert(signal types.Signal) {
     }
     
     b.telegram.NotifyTradeAlert(signal, stopLoss, takeProfit, quantity)
+    b.trackSetup(signal, stopLoss, takeProfit, quantity)
     
     log.Printf("\n⚠️  AUTO-TRADING DISABLED - Execute manually on Binance")
     log.Println(strings.Repeat("=", 60))
+    return true
+}
+
+// trackSetup follows an alerted setup as a paper position, so stops, targets,
+// time exits and strategy exits can alert when to get out
+func (b *Bot) trackSetup(signal types.Signal, stopLoss, takeProfit, quantity float64) {
+    strategyName := signal.Strategy
+    if strategyName == "" {
+        strategyName = "momentum"
+    }
+    notionalRate := signal.NotionalRate
+    if notionalRate <= 0 {
+        notionalRate = 1
+    }
+    
+    b.positions = append(b.positions, types.Position{
+        Symbol:              signal.Symbol,
+        EntryPrice:          signal.Price,
+        CurrentPrice:        signal.Price,
+        HighestPrice:        signal.Price,
+        Quantity:            quantity,
+        Side:                "BUY",
+        StopLoss:            stopLoss,
+        TakeProfit:          takeProfit,
+        TrailingStopEnabled: b.config.Strategy.TrailingStopEnabled,
+        Strategy:            strategyName,
+        NotionalRate:        notionalRate,
+        EntryTime:           time.Now(),
+        LastUpdateTime:      time.Now(),
+    })
+    b.risk.SaveSetups(b.positions)
+}
+
+// riskSize sizes a BUY from risk_percent of equity, the stop distance, the
+// free quote balance and the symbol's exchange filters
+func (b *Bot) riskSize(signal types.Signal, stopLoss, sizeMultiplier float64) (risk.RiskSize, error) {
+    info, available, err := b.sizingInputs(signal)
+    if err != nil {
+        return risk.RiskSize{}, err
+    }
+    return b.risk.CalculateRiskSize(signal.Price, stopLoss, signal.ATR, sizeMultiplier, 
+        available, signal.NotionalRate, info)
+}
+
+// kellySize sizes a BUY at the signal strategy's Kelly fraction of equity and
+// logs how the fraction was derived
+func (b *Bot) kellySize(signal types.Signal, stopLoss, sizeMultiplier float64) (risk.RiskSize, error) {
+    strategyName := signal.Strategy
+    if strategyName == "" {
+        strategyName = "momentum"
+    }
+    info, available, err := b.sizingInputs(signal)
+    if err != nil {
+        return risk.RiskSize{}, err
+    }
+    
+    size, estimate, err := b.risk.CalculateKellySize(strategyName, signal.Price, stopLoss, sizeMultiplier, 
+        available, signal.NotionalRate, info)
+    if estimate.Trades > 0 {
+        log.Printf("   📐 Kelly (%s): %d trades | win rate %.1f%% | avg win %.2f%% / avg loss %.2f%% | full Kelly %.3f x %.2f -> %.2f%% of equity", 
+            strategyName, estimate.Trades, estimate.WinRate*100, estimate.AvgWin, estimate.AvgLoss, 
+            estimate.FullKelly, estimate.Multiplier, estimate.Fraction*100)
+    }
+    return size, err
+}
+
+// sizingInputs returns the exchange filters of a signal's symbol and the
+// balance of its quote asset
+func (b *Bot) sizingInputs(signal types.Signal) (types.SymbolInfo, float64, error) {
+    info, ok := b.strategy.SymbolInfo(signal.Symbol)
+    if !ok {
+        return info, 0, fmt.Errorf("unknown symbol")
+    }
+    balances, err := b.client.GetAccountBalance()
+    if err != nil {
+        return info, 0, fmt.Errorf("failed to get balance: %v", err)
+    }
+    return info, balances[info.QuoteAsset], nil
+}
+
+// updateEquity marks the account and the tracked setups to market, records
+// it on the equity curve and enforces the drawdown limits: entries are
+// blocked by the risk manager, the hard limit sends an exit alert for every
+// tracked setup here. risk_percent and kelly sizing read the equity recorded here.
+func (b *Bot) updateEquity(tickers []types.Ticker) {
+    if b.config.Risk.MaxDrawdown <= 0 && b.config.Risk.FlattenDrawdown <= 0 && 
+        b.config.Risk.PositionSizing.Mode != "risk_percent" && b.config.Risk.PositionSizing.Mode != "kelly" {
+        return
+    }
+    
+    balances, err := b.client.GetAccountBalance()
+    if err != nil {
+        log.Printf("⚠️  Equity not updated: %v", err)
+        return
+    }
+    prices := make(map[string]float64, len(tickers))
+    for _, t := range tickers {
+        prices[t.Symbol] = t.LastPrice
+    }
+    asset := strings.ToUpper(b.config.Strategy.Universe.NotionalAsset)
+    if asset == "" {
+        asset = "USDT"
+    }
+    
+    equity := risk.MarkToMarket(balances, b.positions, prices, asset)
+    if equity <= 0 {
+        return // No balances (e.g. no API keys) - nothing to track
+    }
+    update := b.risk.UpdateEquity(equity)
+    
+    if update.HitMax {
+        log.Printf("🛑 Max drawdown reached: equity %.2f %s is %.2f%% below peak %.2f - blocking new entries", 
+            update.Equity, asset, update.DrawdownPercent, update.Peak)
+        b.telegram.NotifyDrawdown(update.Equity, update.Peak, update.DrawdownPercent, 
+            b.config.Risk.MaxDrawdown, asset, false)
+    }
+    if update.PeakReset {
+        log.Printf("🔄 Blocked for %.0fh - peak equity reset to %.2f %s, new entries allowed", 
+            b.config.Risk.DrawdownResetHours, update.Equity, asset)
+    } else if update.Recovered {
+        log.Printf("✅ Drawdown back to %.2f%% - new entries allowed", update.DrawdownPercent)
+    }
+    if update.HitFlatten {
+        log.Printf("🚨 Drawdown hard limit reached: %.2f%% below peak - exiting %d tracked setups", 
+            update.DrawdownPercent, len(b.positions))
+        b.telegram.NotifyDrawdown(update.Equity, update.Peak, update.DrawdownPercent, 
+            b.config.Risk.FlattenDrawdown, asset, true)
+    }
+    
+    // Setups alerted later are exited too while the hard limit holds
+    if b.risk.ShouldFlatten() && len(b.positions) > 0 {
+        positions := append([]types.Position(nil), b.positions...)
+        for i := range positions {
+            b.closePosition(&positions[i], fmt.Sprintf("Drawdown hard limit: %.2f%% below peak equity", update.DrawdownPercent))
+        }
+    }
 }
 
 func (b *Bot) displayStatus(hotCoinsCount int) {
@@ -335,6 +724,12 @@ func (b *Bot) displayDetailedStatus() {
         log.Printf("📈 No trades executed yet")
     }
     
+    // Equity curve
+    if equity, peak := b.risk.GetEquity(); peak > 0 {
+        log.Printf("\n💼 Equity: %.2f | Peak: %.2f | Drawdown: %.2f%% (max %.2f%%)", 
+            equity, peak, b.risk.DrawdownPercent(), b.config.Risk.MaxDrawdown)
+    }
+    
     // Active positions
     if len(b.positions) > 0 {
         log.Printf("\n📊 Active Positions:")
@@ -359,6 +754,16 @@ func (b *Bot) displayDetailedStatus() {
         }
     }
     
+    // Signals waiting for confirmation
+    if b.confirmer != nil {
+        if pending := b.confirmer.Pending(); len(pending) > 0 {
+            log.Printf("\n⏳ Pending Signals:")
+            for _, p := range pending {
+                log.Printf("   - %s", b.confirmer.Describe(p))
+            }
+        }
+    }
+    
     log.Println(strings.Repeat("=", 70))
 }
 
@@ -372,6 +777,13 @@ func (b *Bot) cleanupAlertedCoins() {
 }
 
 func (b *Bot) updatePositions() {
+    // closePosition rebuilds b.positions, so close after the loop
+    type pendingClose struct {
+        position types.Position
+        reason   string
+    }
+    toClose := []pendingClose{}
+    
     for i := range b.positions {
         pos := &b.positions[i]
         
@@ -380,8 +792,13 @@ func (b *Bot) updatePositions() {
             continue
         }
         
+        notionalRate := pos.NotionalRate
+        if notionalRate <= 0 {
+            notionalRate = 1
+        }
         pos.CurrentPrice = currentPrice
-        pos.PnL = (currentPrice - pos.EntryPrice) * pos.Quantity
+        pos.LastUpdateTime = time.Now()
+        pos.PnL = (currentPrice - pos.EntryPrice) * pos.Quantity * notionalRate
         pos.PnLPercent = ((currentPrice - pos.EntryPrice) / pos.EntryPrice) * 100
         
         if b.risk.UpdateTrailingStop(pos) {
@@ -391,30 +808,39 @@ func (b *Bot) updatePositions() {
         }
         
         shouldClose, reason := b.risk.ShouldClosePosition(*pos)
+        if !shouldClose {
+            if exit := b.strategy.GenerateExitSignal(*pos); exit.Action == "SELL" {
+                shouldClose, reason = true, fmt.Sprintf("Strategy exit: %s", exit.Reason)
+            }
+        }
         if shouldClose {
-            b.closePosition(pos, reason)
+            toClose = append(toClose, pendingClose{*pos, reason})
         }
     }
+    
+    for _, c := range toClose {
+        b.closePosition(&c.position, c.reason)
+    }
+    b.risk.SaveSetups(b.positions) // Trailing stops and highest prices moved
 }
 
+// closePosition ends a tracked setup and alerts the exit. Nothing is sold -
+// like the entry, the exit is executed manually.
 func (b *Bot) closePosition(pos *types.Position, reason string) {
-    log.Printf("\n🔔 Closing position: %s", pos.Symbol)
+    log.Printf("\n🔔 EXIT ALERT - MANUAL ACTION REQUIRED: %s at $%.4f", pos.Symbol, pos.CurrentPrice)
     log.Printf("   Reason: %s", reason)
+    log.Printf("   PnL: %.2f USDT (%.2f%%) from the $%.4f entry", pos.PnL, pos.PnLPercent, pos.EntryPrice)
     
-    _, err := b.client.PlaceMarketOrder(pos.Symbol, "SELL", pos.Quantity)
-    if err != nil {
-        log.Printf("❌ Failed to close position: %v", err)
-        b.telegram.NotifyError(fmt.Sprintf("Failed to close %s: %v", pos.Symbol, err))
-        return
+    // The setup's outcome feeds the win rate and the per-strategy Kelly estimate
+    duration := 0.0
+    if !pos.EntryTime.IsZero() {
+        duration = time.Since(pos.EntryTime).Minutes()
     }
-    
-    log.Printf("✅ Position closed: %s", pos.Symbol)
-    log.Printf("   PnL: %.2f USDT (%.2f%%)", pos.PnL, pos.PnLPercent)
-    
-    // NEW: Record trade for performance tracking
-    entryTime := time.Now() // You should track actual entry time in Position struct
-    duration := time.Since(entryTime).Minutes()
-    b.risk.RecordTrade(pos.Symbol, pos.PnL, duration)
+    strategyName := pos.Strategy
+    if strategyName == "" {
+        strategyName = "momentum"
+    }
+    b.risk.RecordTrade(pos.Symbol, strategyName, pos.PnL, pos.PnLPercent, duration)
     
     b.risk.UpdateDailyPnL(pos.PnL)
     
@@ -432,6 +858,7 @@ func (b *Bot) closePosition(pos *types.Position, reason string) {
         }
     }
     b.positions = newPositions
+    b.risk.SaveSetups(b.positions)
 }
 
 func (b *Bot) checkDailyReport() {
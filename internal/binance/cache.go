@@ -0,0 +1,136 @@
+// File: internal/binance/cache.go
+// ============================================
+package binance
+
+import (
+    "binance-trading-bot/pkg/types"
+    "math"
+    "sync"
+    "time"
+)
+
+// klineCacheMinBars is the minimum number of klines fetched per request, so
+// callers asking for 50 and 100 bars share one response
+const klineCacheMinBars = 100
+
+type klineEntry struct {
+    klines    []types.Kline
+    covers    int // Largest limit the entry can answer
+    fetchedAt time.Time
+}
+
+// klineCall is an in-flight request that concurrent callers wait on
+type klineCall struct {
+    done   chan struct{}
+    limit  int // Largest limit the response can answer
+    klines []types.Kline
+    err    error
+}
+
+// KlineCache keeps klines per symbol and interval for a short TTL and
+// deduplicates concurrent requests for the same key
+type KlineCache struct {
+    mu       sync.Mutex
+    ttl      time.Duration
+    entries  map[string]klineEntry
+    inflight map[string]*klineCall
+    hits     int
+    misses   int
+}
+
+// NewKlineCache creates a cache; a zero ttl disables caching but still
+// deduplicates concurrent requests
+func NewKlineCache(ttl time.Duration) *KlineCache {
+    return &KlineCache{
+        ttl:      ttl,
+        entries:  make(map[string]klineEntry),
+        inflight: make(map[string]*klineCall),
+    }
+}
+
+// lastN returns the last n klines (or all of them if there are fewer)
+func lastN(klines []types.Kline, n int) []types.Kline {
+    if len(klines) > n {
+        klines = klines[len(klines)-n:]
+    }
+    result := make([]types.Kline, len(klines))
+    copy(result, klines)
+    return result
+}
+
+// Get returns cached klines or calls fetch with at least klineCacheMinBars
+func (c *KlineCache) Get(symbol, interval string, limit int, fetch func(limit int) ([]types.Kline, error)) ([]types.Kline, error) {
+    key := symbol + "|" + interval
+    fetchLimit := limit
+    if fetchLimit < klineCacheMinBars {
+        fetchLimit = klineCacheMinBars
+    }
+
+    for {
+        c.mu.Lock()
+        if entry, ok := c.entries[key]; ok && time.Since(entry.fetchedAt) < c.ttl && entry.covers >= limit {
+            c.hits++
+            c.mu.Unlock()
+            return lastN(entry.klines, limit), nil
+        }
+
+        if call, ok := c.inflight[key]; ok {
+            c.mu.Unlock()
+            <-call.done
+            if call.err == nil && call.limit >= limit {
+                c.mu.Lock()
+                c.hits++
+                c.mu.Unlock()
+                return lastN(call.klines, limit), nil
+            }
+            if call.err != nil {
+                return nil, call.err
+            }
+            continue // The shared request was too short, try again
+        }
+
+        call := &klineCall{done: make(chan struct{}), limit: fetchLimit}
+        c.inflight[key] = call
+        c.misses++
+        c.mu.Unlock()
+
+        call.klines, call.err = fetch(fetchLimit)
+        // Fewer klines than asked means the symbol has no more history
+        if call.err == nil && len(call.klines) < fetchLimit {
+            call.limit = math.MaxInt32
+        }
+
+        c.mu.Lock()
+        delete(c.inflight, key)
+        if call.err == nil && c.ttl > 0 {
+            c.entries[key] = klineEntry{klines: call.klines, covers: call.limit, fetchedAt: time.Now()}
+        }
+        c.mu.Unlock()
+        close(call.done)
+
+        if call.err != nil {
+            return nil, call.err
+        }
+        return lastN(call.klines, limit), nil
+    }
+}
+
+// Stats returns cache hits and misses since the last call and resets them
+func (c *KlineCache) Stats() (hits, misses int) {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    hits, misses = c.hits, c.misses
+    c.hits, c.misses = 0, 0
+    return hits, misses
+}
+
+// Prune drops expired entries
+func (c *KlineCache) Prune() {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    for key, entry := range c.entries {
+        if time.Since(entry.fetchedAt) >= c.ttl {
+            delete(c.entries, key)
+        }
+    }
+}
@@ -0,0 +1,197 @@
+// File: internal/binance/cache_test.go
+// ============================================
+package binance
+
+import (
+    "errors"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+
+    "binance-trading-bot/pkg/types"
+)
+
+// countingFetch returns n klines per request and counts the requests. When
+// release is set, every request blocks until it is closed.
+type countingFetch struct {
+    calls   int32
+    bars    int // History available; 0 means unlimited
+    err     error
+    release chan struct{}
+}
+
+func (f *countingFetch) fetch(limit int) ([]types.Kline, error) {
+    atomic.AddInt32(&f.calls, 1)
+    if f.release != nil {
+        <-f.release
+    }
+    if f.err != nil {
+        return nil, f.err
+    }
+    n := limit
+    if f.bars > 0 && f.bars < n {
+        n = f.bars
+    }
+    klines := make([]types.Kline, n)
+    for i := range klines {
+        klines[i] = types.Kline{OpenTime: time.Unix(int64(i)*60, 0), Close: float64(i)}
+    }
+    return klines, nil
+}
+
+func (f *countingFetch) count() int {
+    return int(atomic.LoadInt32(&f.calls))
+}
+
+// getConcurrently runs n Gets for the same key and waits for them to block
+// on the shared request before releasing it
+func getConcurrently(t *testing.T, c *KlineCache, f *countingFetch, n, limit int) []error {
+    t.Helper()
+    errs := make([]error, n)
+    lengths := make([]int, n)
+    var wg sync.WaitGroup
+    for i := 0; i < n; i++ {
+        wg.Add(1)
+        go func(i int) {
+            defer wg.Done()
+            klines, err := c.Get("BTCUSDT", "1m", limit, f.fetch)
+            errs[i], lengths[i] = err, len(klines)
+        }(i)
+    }
+    time.Sleep(50 * time.Millisecond) // Let the callers queue on the in-flight request
+    close(f.release)
+    wg.Wait()
+
+    for i, err := range errs {
+        if err == nil && lengths[i] != limit {
+            t.Errorf("caller %d got %d klines, want %d", i, lengths[i], limit)
+        }
+    }
+    return errs
+}
+
+func TestKlineCacheDeduplicatesConcurrentRequests(t *testing.T) {
+    c := NewKlineCache(time.Minute)
+    f := &countingFetch{release: make(chan struct{})}
+
+    for i, err := range getConcurrently(t, c, f, 20, 50) {
+        if err != nil {
+            t.Errorf("caller %d: %v", i, err)
+        }
+    }
+    if f.count() != 1 {
+        t.Errorf("fetches = %d, want 1", f.count())
+    }
+
+    // The shared response is cached for later callers too
+    if _, err := c.Get("BTCUSDT", "1m", 100, f.fetch); err != nil {
+        t.Fatal(err)
+    }
+    if f.count() != 1 {
+        t.Errorf("fetches after cached Get = %d, want 1", f.count())
+    }
+    if hits, misses := c.Stats(); hits != 20 || misses != 1 {
+        t.Errorf("stats = %d hits, %d misses, want 20 and 1", hits, misses)
+    }
+}
+
+func TestKlineCacheLongerRequestRefetches(t *testing.T) {
+    c := NewKlineCache(time.Minute)
+    f := &countingFetch{}
+
+    klines, err := c.Get("BTCUSDT", "1m", 50, f.fetch)
+    if err != nil || len(klines) != 50 {
+        t.Fatalf("50 bars: %d klines, %v", len(klines), err)
+    }
+    klines, err = c.Get("BTCUSDT", "1m", 200, f.fetch)
+    if err != nil || len(klines) != 200 {
+        t.Fatalf("200 bars: %d klines, %v", len(klines), err)
+    }
+    if f.count() != 2 {
+        t.Errorf("fetches = %d, want 2", f.count())
+    }
+
+    // Both lengths are now answered from the 200-bar entry
+    for _, limit := range []int{50, 100, 200} {
+        if _, err := c.Get("BTCUSDT", "1m", limit, f.fetch); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if f.count() != 2 {
+        t.Errorf("fetches after cached Gets = %d, want 2", f.count())
+    }
+}
+
+func TestKlineCacheShortHistoryCoversAnyLimit(t *testing.T) {
+    c := NewKlineCache(time.Minute)
+    f := &countingFetch{bars: 30} // A new listing with 30 bars of history
+
+    for _, limit := range []int{50, 500} {
+        klines, err := c.Get("NEWUSDT", "1m", limit, f.fetch)
+        if err != nil || len(klines) != 30 {
+            t.Fatalf("%d bars: %d klines, %v", limit, len(klines), err)
+        }
+    }
+    if f.count() != 1 {
+        t.Errorf("fetches = %d, want 1", f.count())
+    }
+}
+
+func TestKlineCacheRefetchesExpiredEntries(t *testing.T) {
+    c := NewKlineCache(time.Minute)
+    f := &countingFetch{}
+
+    if _, err := c.Get("BTCUSDT", "1m", 50, f.fetch); err != nil {
+        t.Fatal(err)
+    }
+    c.mu.Lock()
+    entry := c.entries["BTCUSDT|1m"]
+    entry.fetchedAt = entry.fetchedAt.Add(-2 * time.Minute)
+    c.entries["BTCUSDT|1m"] = entry
+    c.mu.Unlock()
+
+    if _, err := c.Get("BTCUSDT", "1m", 50, f.fetch); err != nil {
+        t.Fatal(err)
+    }
+    if f.count() != 2 {
+        t.Errorf("fetches = %d, want 2", f.count())
+    }
+
+    c.Prune()
+    if len(c.entries) != 1 {
+        t.Errorf("Prune dropped the fresh entry")
+    }
+
+    // Other keys are cached separately
+    if _, err := c.Get("BTCUSDT", "5m", 50, f.fetch); err != nil {
+        t.Fatal(err)
+    }
+    if f.count() != 3 {
+        t.Errorf("fetches = %d, want 3", f.count())
+    }
+}
+
+func TestKlineCachePropagatesErrorsToWaiters(t *testing.T) {
+    c := NewKlineCache(time.Minute)
+    fetchErr := errors.New("rate limited")
+    f := &countingFetch{err: fetchErr, release: make(chan struct{})}
+
+    for i, err := range getConcurrently(t, c, f, 10, 50) {
+        if !errors.Is(err, fetchErr) {
+            t.Errorf("caller %d: err = %v, want %v", i, err, fetchErr)
+        }
+    }
+    if f.count() != 1 {
+        t.Errorf("fetches = %d, want 1", f.count())
+    }
+
+    // Errors are not cached
+    f.err = nil
+    if _, err := c.Get("BTCUSDT", "1m", 50, f.fetch); err != nil {
+        t.Fatalf("retry after error: %v", err)
+    }
+    if f.count() != 2 {
+        t.Errorf("fetches after retry = %d, want 2", f.count())
+    }
+}
@@ -22,24 +22,90 @@ type Client struct {
     secretKey  string
     baseURL    string
     httpClient *http.Client
+    limiter    *RateLimiter
+    klines     *KlineCache
 }
 
-func NewClient(apiKey, secretKey string, testnet bool) *Client {
+// ClientOptions tunes request throttling and kline caching
+type ClientOptions struct {
+    WeightPerMinute int           // Request weight budget (Binance allows 6000, default 1200)
+    KlineCacheTTL   time.Duration // How long fetched klines are reused (0 = no caching)
+}
+
+func NewClient(apiKey, secretKey string, testnet bool, opts ClientOptions) *Client {
     baseURL := "https://api.binance.com"
     if testnet {
         baseURL = "https://testnet.binance.vision"
     }
     
-    log.Printf("🔧 Binance Client initialized with baseURL: %s", baseURL)
+    if opts.WeightPerMinute <= 0 {
+        opts.WeightPerMinute = 1200
+    }
+    
+    log.Printf("🔧 Binance Client initialized with baseURL: %s (weight %d/min, kline cache %s)",
+        baseURL, opts.WeightPerMinute, opts.KlineCacheTTL)
     
     return &Client{
         apiKey:     apiKey,
         secretKey:  secretKey,
         baseURL:    baseURL,
         httpClient: &http.Client{Timeout: 10 * time.Second},
+        limiter:    NewRateLimiter(opts.WeightPerMinute),
+        klines:     NewKlineCache(opts.KlineCacheTTL),
     }
 }
 
+// do sends a request once the rate limiter grants its weight. On HTTP 429 or
+// 418 all requests pause for the Retry-After period.
+func (c *Client) do(req *http.Request, weight int) (*http.Response, error) {
+    c.limiter.Wait(weight)
+    
+    resp, err := c.httpClient.Do(req)
+    if err != nil {
+        return nil, err
+    }
+    
+    if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusTeapot {
+        retryAfter := 60 * time.Second
+        if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+            retryAfter = time.Duration(seconds) * time.Second
+        }
+        log.Printf("⚠️  Binance rate limit hit (status %d) - pausing requests for %s", resp.StatusCode, retryAfter)
+        c.limiter.Pause(time.Now().Add(retryAfter))
+    }
+    
+    return resp, nil
+}
+
+// get sends a public GET request through the rate limiter
+func (c *Client) get(url string, weight int) (*http.Response, error) {
+    req, err := http.NewRequest("GET", url, nil)
+    if err != nil {
+        return nil, err
+    }
+    return c.do(req, weight)
+}
+
+// klinesWeight is the request weight of /api/v3/klines for a limit
+func klinesWeight(limit int) int {
+    switch {
+    case limit < 100:
+        return 1
+    case limit < 500:
+        return 2
+    case limit <= 1000:
+        return 5
+    default:
+        return 10
+    }
+}
+
+// KlineCacheStats returns kline cache hits and misses since the last call
+func (c *Client) KlineCacheStats() (hits, misses int) {
+    c.klines.Prune()
+    return c.klines.Stats()
+}
+
 func (c *Client) sign(params string) string {
     mac := hmac.New(sha256.New, []byte(c.secretKey))
     mac.Write([]byte(params))
@@ -51,7 +117,7 @@ func (c *Client) Get24hrTickers() ([]types.Ticker, error) {
     
     log.Printf("📡 Fetching tickers from: %s", url)
     
-    resp, err := c.httpClient.Get(url)
+    resp, err := c.get(url, 80)
     if err != nil {
         log.Printf("❌ HTTP request failed: %v", err)
         return nil, fmt.Errorf("HTTP request failed: %v", err)
@@ -95,6 +161,7 @@ func (c *Client) Get24hrTickers() ([]types.Ticker, error) {
         priceChange, _ := strconv.ParseFloat(raw["priceChange"].(string), 64)
         priceChangePercent, _ := strconv.ParseFloat(raw["priceChangePercent"].(string), 64)
         lastPrice, _ := strconv.ParseFloat(raw["lastPrice"].(string), 64)
+        weightedAvgPrice, _ := strconv.ParseFloat(raw["weightedAvgPrice"].(string), 64)
         volume, _ := strconv.ParseFloat(raw["volume"].(string), 64)
         quoteVolume, _ := strconv.ParseFloat(raw["quoteVolume"].(string), 64)
         
@@ -103,6 +170,86 @@ func (c *Client) Get24hrTickers() ([]types.Ticker, error) {
             PriceChange:        priceChange,
             PriceChangePercent: priceChangePercent,
             LastPrice:          lastPrice,
+            WeightedAvgPrice:   weightedAvgPrice,
+            Volume:             volume,
+            QuoteVolume:        quoteVolume,
+            Timestamp:          time.Now(),
+        })
+    }
+    
+    return tickers, nil
+}
+
+// GetRollingTickers returns price change statistics over a rolling window
+// ("1m"-"59m", "1h"-"23h", "1d"-"7d") from /api/v3/ticker, 100 symbols per request
+func (c *Client) GetRollingTickers(symbols []string, windowSize string) ([]types.Ticker, error) {
+    var tickers []types.Ticker
+    for start := 0; start < len(symbols); start += 100 {
+        end := start + 100
+        if end > len(symbols) {
+            end = len(symbols)
+        }
+        batch, err := c.fetchRollingTickers(symbols[start:end], windowSize)
+        if err != nil {
+            return nil, err
+        }
+        tickers = append(tickers, batch...)
+    }
+    return tickers, nil
+}
+
+func (c *Client) fetchRollingTickers(symbols []string, windowSize string) ([]types.Ticker, error) {
+    encoded, _ := json.Marshal(symbols)
+    reqURL := fmt.Sprintf("%s/api/v3/ticker?symbols=%s&windowSize=%s",
+        c.baseURL, url.QueryEscape(string(encoded)), windowSize)
+    
+    weight := 4 * len(symbols)
+    if weight > 200 {
+        weight = 200
+    }
+    
+    resp, err := c.get(reqURL, weight)
+    if err != nil {
+        return nil, fmt.Errorf("HTTP request failed: %v", err)
+    }
+    defer resp.Body.Close()
+    
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read response: %v", err)
+    }
+    if resp.StatusCode != 200 {
+        return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+    }
+    
+    var raw []struct {
+        Symbol             string `json:"symbol"`
+        PriceChange        string `json:"priceChange"`
+        PriceChangePercent string `json:"priceChangePercent"`
+        WeightedAvgPrice   string `json:"weightedAvgPrice"`
+        LastPrice          string `json:"lastPrice"`
+        Volume             string `json:"volume"`
+        QuoteVolume        string `json:"quoteVolume"`
+    }
+    if err := json.Unmarshal(body, &raw); err != nil {
+        return nil, fmt.Errorf("unmarshal error: %v", err)
+    }
+    
+    tickers := make([]types.Ticker, 0, len(raw))
+    for _, r := range raw {
+        priceChange, _ := strconv.ParseFloat(r.PriceChange, 64)
+        priceChangePercent, _ := strconv.ParseFloat(r.PriceChangePercent, 64)
+        weightedAvgPrice, _ := strconv.ParseFloat(r.WeightedAvgPrice, 64)
+        lastPrice, _ := strconv.ParseFloat(r.LastPrice, 64)
+        volume, _ := strconv.ParseFloat(r.Volume, 64)
+        quoteVolume, _ := strconv.ParseFloat(r.QuoteVolume, 64)
+        
+        tickers = append(tickers, types.Ticker{
+            Symbol:             r.Symbol,
+            PriceChange:        priceChange,
+            PriceChangePercent: priceChangePercent,
+            LastPrice:          lastPrice,
+            WeightedAvgPrice:   weightedAvgPrice,
             Volume:             volume,
             QuoteVolume:        quoteVolume,
             Timestamp:          time.Now(),
@@ -112,20 +259,37 @@ func (c *Client) Get24hrTickers() ([]types.Ticker, error) {
     return tickers, nil
 }
 
+// GetKlines returns the latest klines, served from the TTL cache when possible.
+// Concurrent requests for the same symbol and interval share one API call.
 func (c *Client) GetKlines(symbol, interval string, limit int) ([]types.Kline, error) {
+    return c.klines.Get(symbol, interval, limit, func(fetchLimit int) ([]types.Kline, error) {
+        return c.fetchKlines(symbol, interval, fetchLimit)
+    })
+}
+
+func (c *Client) fetchKlines(symbol, interval string, limit int) ([]types.Kline, error) {
     url := fmt.Sprintf("%s/api/v3/klines?symbol=%s&interval=%s&limit=%d",
         c.baseURL, symbol, interval, limit)
     
-    resp, err := c.httpClient.Get(url)
+    resp, err := c.get(url, klinesWeight(limit))
     if err != nil {
         return nil, err
     }
     defer resp.Body.Close()
     
-    body, _ := io.ReadAll(resp.Body)
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read response: %v", err)
+    }
+    
+    if resp.StatusCode != 200 {
+        return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+    }
     
     var rawKlines [][]interface{}
-    json.Unmarshal(body, &rawKlines)
+    if err := json.Unmarshal(body, &rawKlines); err != nil {
+        return nil, fmt.Errorf("unmarshal error: %v", err)
+    }
     
     var klines []types.Kline
     for _, k := range rawKlines {
@@ -136,21 +300,191 @@ func (c *Client) GetKlines(symbol, interval string, limit int) ([]types.Kline, e
         close, _ := strconv.ParseFloat(k[4].(string), 64)
         volume, _ := strconv.ParseFloat(k[5].(string), 64)
         closeTime := time.UnixMilli(int64(k[6].(float64)))
+        quoteVolume := 0.0
+        if len(k) > 7 {
+            quoteVolume, _ = strconv.ParseFloat(k[7].(string), 64)
+        }
         
         klines = append(klines, types.Kline{
-            OpenTime:  openTime,
-            Open:      open,
-            High:      high,
-            Low:       low,
-            Close:     close,
-            Volume:    volume,
-            CloseTime: closeTime,
+            OpenTime:    openTime,
+            Open:        open,
+            High:        high,
+            Low:         low,
+            Close:       close,
+            Volume:      volume,
+            CloseTime:   closeTime,
+            QuoteVolume: quoteVolume,
         })
     }
     
     return klines, nil
 }
 
+// GetExchangeInfo returns the trading rules of every spot symbol
+func (c *Client) GetExchangeInfo() ([]types.SymbolInfo, error) {
+    url := fmt.Sprintf("%s/api/v3/exchangeInfo", c.baseURL)
+    
+    resp, err := c.get(url, 20)
+    if err != nil {
+        return nil, fmt.Errorf("HTTP request failed: %v", err)
+    }
+    defer resp.Body.Close()
+    
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read response: %v", err)
+    }
+    if resp.StatusCode != 200 {
+        return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+    }
+    
+    var info struct {
+        Symbols []struct {
+            Symbol         string     `json:"symbol"`
+            Status         string     `json:"status"`
+            BaseAsset      string     `json:"baseAsset"`
+            QuoteAsset     string     `json:"quoteAsset"`
+            Permissions    []string   `json:"permissions"`
+            PermissionSets [][]string `json:"permissionSets"`
+            Filters        []struct {
+                FilterType  string `json:"filterType"`
+                StepSize    string `json:"stepSize"`
+                MinQty      string `json:"minQty"`
+                MaxQty      string `json:"maxQty"`
+                MinNotional string `json:"minNotional"`
+                MaxNotional string `json:"maxNotional"`
+            } `json:"filters"`
+        } `json:"symbols"`
+    }
+    if err := json.Unmarshal(body, &info); err != nil {
+        return nil, fmt.Errorf("unmarshal error: %v", err)
+    }
+    
+    symbols := make([]types.SymbolInfo, 0, len(info.Symbols))
+    for _, s := range info.Symbols {
+        permissions := s.Permissions
+        for _, set := range s.PermissionSets {
+            permissions = append(permissions, set...)
+        }
+        symbol := types.SymbolInfo{
+            Symbol:      s.Symbol,
+            Status:      s.Status,
+            BaseAsset:   s.BaseAsset,
+            QuoteAsset:  s.QuoteAsset,
+            Permissions: permissions,
+        }
+        for _, f := range s.Filters {
+            switch f.FilterType {
+            case "LOT_SIZE":
+                symbol.StepSize, _ = strconv.ParseFloat(f.StepSize, 64)
+                symbol.MinQty, _ = strconv.ParseFloat(f.MinQty, 64)
+                symbol.MaxQty, _ = strconv.ParseFloat(f.MaxQty, 64)
+            case "MIN_NOTIONAL", "NOTIONAL":
+                symbol.MinNotional, _ = strconv.ParseFloat(f.MinNotional, 64)
+                symbol.MaxNotional, _ = strconv.ParseFloat(f.MaxNotional, 64)
+            }
+        }
+        symbols = append(symbols, symbol)
+    }
+    
+    return symbols, nil
+}
+
+// GetAggTrades returns the most recent compressed trades, oldest first
+func (c *Client) GetAggTrades(symbol string, limit int) ([]types.AggTrade, error) {
+    url := fmt.Sprintf("%s/api/v3/aggTrades?symbol=%s&limit=%d", c.baseURL, symbol, limit)
+    
+    resp, err := c.get(url, 4)
+    if err != nil {
+        return nil, fmt.Errorf("HTTP request failed: %v", err)
+    }
+    defer resp.Body.Close()
+    
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read response: %v", err)
+    }
+    if resp.StatusCode != 200 {
+        return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+    }
+    
+    var raw []struct {
+        Price      string `json:"p"`
+        Quantity   string `json:"q"`
+        Time       int64  `json:"T"`
+        BuyerMaker bool   `json:"m"`
+    }
+    if err := json.Unmarshal(body, &raw); err != nil {
+        return nil, fmt.Errorf("unmarshal error: %v", err)
+    }
+    
+    trades := make([]types.AggTrade, 0, len(raw))
+    for _, r := range raw {
+        price, _ := strconv.ParseFloat(r.Price, 64)
+        quantity, _ := strconv.ParseFloat(r.Quantity, 64)
+        trades = append(trades, types.AggTrade{
+            Price:      price,
+            Quantity:   quantity,
+            Time:       time.UnixMilli(r.Time),
+            BuyerMaker: r.BuyerMaker,
+        })
+    }
+    
+    return trades, nil
+}
+
+// GetOrderBook returns a depth snapshot with up to limit levels per side
+func (c *Client) GetOrderBook(symbol string, limit int) (*types.OrderBook, error) {
+    url := fmt.Sprintf("%s/api/v3/depth?symbol=%s&limit=%d", c.baseURL, symbol, limit)
+    
+    weight := 5
+    switch {
+    case limit > 1000:
+        weight = 250
+    case limit > 500:
+        weight = 50
+    case limit > 100:
+        weight = 25
+    }
+    
+    resp, err := c.get(url, weight)
+    if err != nil {
+        return nil, fmt.Errorf("HTTP request failed: %v", err)
+    }
+    defer resp.Body.Close()
+    
+    body, err := io.ReadAll(resp.Body)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read response: %v", err)
+    }
+    if resp.StatusCode != 200 {
+        return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, string(body))
+    }
+    
+    var raw struct {
+        Bids [][]string `json:"bids"`
+        Asks [][]string `json:"asks"`
+    }
+    if err := json.Unmarshal(body, &raw); err != nil {
+        return nil, fmt.Errorf("unmarshal error: %v", err)
+    }
+    
+    levels := func(entries [][]string) []types.BookLevel {
+        result := make([]types.BookLevel, 0, len(entries))
+        for _, e := range entries {
+            if len(e) < 2 {
+                continue
+            }
+            price, _ := strconv.ParseFloat(e[0], 64)
+            quantity, _ := strconv.ParseFloat(e[1], 64)
+            result = append(result, types.BookLevel{Price: price, Quantity: quantity})
+        }
+        return result
+    }
+    
+    return &types.OrderBook{Bids: levels(raw.Bids), Asks: levels(raw.Asks)}, nil
+}
+
 func (c *Client) GetAccountBalance() (map[string]float64, error) {
     timestamp := time.Now().UnixMilli()
     params := fmt.Sprintf("timestamp=%d", timestamp)
@@ -161,7 +495,7 @@ func (c *Client) GetAccountBalance() (map[string]float64, error) {
     req, _ := http.NewRequest("GET", url, nil)
     req.Header.Set("X-MBX-APIKEY", c.apiKey)
     
-    resp, err := c.httpClient.Do(req)
+    resp, err := c.do(req, 20)
     if err != nil {
         return nil, err
     }
@@ -210,7 +544,7 @@ func (c *Client) PlaceMarketOrder(symbol, side string, quantity float64) (*types
     req, _ := http.NewRequest("POST", reqURL, nil)
     req.Header.Set("X-MBX-APIKEY", c.apiKey)
     
-    resp, err := c.httpClient.Do(req)
+    resp, err := c.do(req, 1)
     if err != nil {
         return nil, err
     }
@@ -241,7 +575,7 @@ func (c *Client) PlaceMarketOrder(symbol, side string, quantity float64) (*types
 func (c *Client) GetCurrentPrice(symbol string) (float64, error) {
     url := fmt.Sprintf("%s/api/v3/ticker/price?symbol=%s", c.baseURL, symbol)
     
-    resp, err := c.httpClient.Get(url)
+    resp, err := c.get(url, 2)
     if err != nil {
         return 0, err
     }
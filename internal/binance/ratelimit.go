@@ -0,0 +1,66 @@
+// File: internal/binance/ratelimit.go
+// ============================================
+package binance
+
+import (
+    "sync"
+    "time"
+)
+
+// RateLimiter is a token bucket over Binance request weight. Tokens refill
+// continuously so the budget per minute is never exceeded.
+type RateLimiter struct {
+    mu           sync.Mutex
+    capacity     float64
+    tokens       float64
+    refillPerSec float64
+    last         time.Time
+    pausedUntil  time.Time
+}
+
+// NewRateLimiter allows weightPerMinute request weight per minute
+func NewRateLimiter(weightPerMinute int) *RateLimiter {
+    capacity := float64(weightPerMinute)
+    return &RateLimiter{
+        capacity:     capacity,
+        tokens:       capacity,
+        refillPerSec: capacity / 60,
+        last:         time.Now(),
+    }
+}
+
+// Wait blocks until weight tokens are available and takes them
+func (r *RateLimiter) Wait(weight int) {
+    for {
+        r.mu.Lock()
+        now := time.Now()
+        r.tokens += now.Sub(r.last).Seconds() * r.refillPerSec
+        if r.tokens > r.capacity {
+            r.tokens = r.capacity
+        }
+        r.last = now
+
+        var delay time.Duration
+        if now.Before(r.pausedUntil) {
+            delay = r.pausedUntil.Sub(now)
+        } else if r.tokens >= float64(weight) || float64(weight) > r.capacity {
+            r.tokens -= float64(weight)
+            r.mu.Unlock()
+            return
+        } else {
+            delay = time.Duration((float64(weight) - r.tokens) / r.refillPerSec * float64(time.Second))
+        }
+        r.mu.Unlock()
+
+        time.Sleep(delay)
+    }
+}
+
+// Pause stops all requests until the given time, e.g. after an HTTP 429
+func (r *RateLimiter) Pause(until time.Time) {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    if until.After(r.pausedUntil) {
+        r.pausedUntil = until
+    }
+}
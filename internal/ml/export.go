@@ -0,0 +1,55 @@
+// File: internal/ml/export.go
+// ============================================
+package ml
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "sync"
+    "time"
+)
+
+// FeatureRecord is one exported row. Label it offline from the alert outcome.
+type FeatureRecord struct {
+    Time             time.Time          `json:"time"`
+    Symbol           string             `json:"symbol"`
+    Price            float64            `json:"price"`
+    Action           string             `json:"action"`
+    RuleStrength     float64            `json:"rule_strength"`
+    ModelProbability *float64           `json:"model_probability,omitempty"`
+    Features         map[string]float64 `json:"features"`
+}
+
+// FeatureWriter appends feature records to a JSON lines file
+type FeatureWriter struct {
+    mu   sync.Mutex
+    file *os.File
+}
+
+// NewFeatureWriter opens (or creates) the export file for appending
+func NewFeatureWriter(path string) (*FeatureWriter, error) {
+    file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+    if err != nil {
+        return nil, fmt.Errorf("failed to open feature export %s: %v", path, err)
+    }
+    return &FeatureWriter{file: file}, nil
+}
+
+// Write appends one record; safe for concurrent use
+func (w *FeatureWriter) Write(record FeatureRecord) error {
+    line, err := json.Marshal(record)
+    if err != nil {
+        return err
+    }
+
+    w.mu.Lock()
+    defer w.mu.Unlock()
+    _, err = w.file.Write(append(line, '\n'))
+    return err
+}
+
+// Close closes the export file
+func (w *FeatureWriter) Close() error {
+    return w.file.Close()
+}
@@ -0,0 +1,227 @@
+// File: internal/ml/model.go
+// ============================================
+package ml
+
+import (
+    "encoding/json"
+    "fmt"
+    "math"
+    "os"
+)
+
+// Model scores a feature vector with the probability that a signal wins
+type Model interface {
+    Predict(features map[string]float64) float64
+    Features() []string
+}
+
+// modelFile is the JSON layout shared by every model type
+type modelFile struct {
+    Type     string   `json:"type"` // "logistic" or "tree_ensemble"
+    Features []string `json:"features"`
+
+    // Logistic regression
+    Intercept    float64   `json:"intercept"`
+    Coefficients []float64 `json:"coefficients"`
+    Mean         []float64 `json:"mean"` // Optional standardization
+    Scale        []float64 `json:"scale"`
+
+    // Tree ensemble
+    BaseScore float64 `json:"base_score"`
+    Link      string  `json:"link"` // "logistic" (boosting, default) or "average" (random forest)
+    Trees     []Tree  `json:"trees"`
+}
+
+// LoadModel reads a model from a JSON file
+func LoadModel(path string) (Model, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to read model: %v", err)
+    }
+
+    var file modelFile
+    if err := json.Unmarshal(data, &file); err != nil {
+        return nil, fmt.Errorf("failed to parse model %s: %v", path, err)
+    }
+    if len(file.Features) == 0 {
+        return nil, fmt.Errorf("model %s lists no features", path)
+    }
+
+    switch file.Type {
+    case "logistic":
+        return newLogisticModel(file)
+    case "tree_ensemble":
+        return newTreeEnsemble(file)
+    default:
+        return nil, fmt.Errorf("model %s has unknown type %q (logistic, tree_ensemble)", path, file.Type)
+    }
+}
+
+func sigmoid(x float64) float64 {
+    return 1 / (1 + math.Exp(-x))
+}
+
+// vector orders features as the model expects. Missing features are NaN.
+func vector(names []string, features map[string]float64) []float64 {
+    x := make([]float64, len(names))
+    for i, name := range names {
+        v, ok := features[name]
+        if !ok {
+            v = math.NaN()
+        }
+        x[i] = v
+    }
+    return x
+}
+
+// ============================================
+// Logistic regression
+// ============================================
+
+type LogisticModel struct {
+    features     []string
+    intercept    float64
+    coefficients []float64
+    mean         []float64
+    scale        []float64
+}
+
+func newLogisticModel(file modelFile) (*LogisticModel, error) {
+    n := len(file.Features)
+    if len(file.Coefficients) != n {
+        return nil, fmt.Errorf("logistic model has %d coefficients for %d features", len(file.Coefficients), n)
+    }
+    if (len(file.Mean) != 0 && len(file.Mean) != n) || (len(file.Scale) != 0 && len(file.Scale) != n) {
+        return nil, fmt.Errorf("logistic model mean/scale must have %d values", n)
+    }
+    return &LogisticModel{
+        features:     file.Features,
+        intercept:    file.Intercept,
+        coefficients: file.Coefficients,
+        mean:         file.Mean,
+        scale:        file.Scale,
+    }, nil
+}
+
+func (m *LogisticModel) Features() []string { return m.features }
+
+// Predict returns sigmoid(intercept + sum(coef * standardized feature)).
+// Missing features contribute nothing (the training mean).
+func (m *LogisticModel) Predict(features map[string]float64) float64 {
+    z := m.intercept
+    for i, v := range vector(m.features, features) {
+        if math.IsNaN(v) {
+            continue
+        }
+        if len(m.mean) > 0 {
+            v -= m.mean[i]
+        }
+        if len(m.scale) > 0 && m.scale[i] != 0 {
+            v /= m.scale[i]
+        }
+        z += m.coefficients[i] * v
+    }
+    return sigmoid(z)
+}
+
+// ============================================
+// Decision tree ensemble
+// ============================================
+
+// Tree is a flattened binary tree. Node 0 is the root.
+type Tree struct {
+    Nodes []TreeNode `json:"nodes"`
+}
+
+// TreeNode splits on features[Feature] < Threshold, or is a leaf when Feature < 0
+type TreeNode struct {
+    Feature     int     `json:"feature"`
+    Threshold   float64 `json:"threshold"`
+    Left        int     `json:"left"`
+    Right       int     `json:"right"`
+    Value       float64 `json:"value"`        // Leaf output
+    MissingLeft bool    `json:"missing_left"` // Direction for missing features
+}
+
+type TreeEnsemble struct {
+    features  []string
+    baseScore float64
+    link      string
+    trees     []Tree
+}
+
+func newTreeEnsemble(file modelFile) (*TreeEnsemble, error) {
+    if len(file.Trees) == 0 {
+        return nil, fmt.Errorf("tree ensemble has no trees")
+    }
+    link := file.Link
+    if link == "" {
+        link = "logistic"
+    }
+    if link != "logistic" && link != "average" {
+        return nil, fmt.Errorf("tree ensemble has unknown link %q (logistic, average)", link)
+    }
+
+    // Validate node references so Predict can't loop or index out of range
+    for t, tree := range file.Trees {
+        if len(tree.Nodes) == 0 {
+            return nil, fmt.Errorf("tree %d has no nodes", t)
+        }
+        for i, node := range tree.Nodes {
+            if node.Feature < 0 {
+                continue
+            }
+            if node.Feature >= len(file.Features) {
+                return nil, fmt.Errorf("tree %d node %d uses feature %d of %d", t, i, node.Feature, len(file.Features))
+            }
+            if node.Left <= i || node.Right <= i || node.Left >= len(tree.Nodes) || node.Right >= len(tree.Nodes) {
+                return nil, fmt.Errorf("tree %d node %d has invalid children", t, i)
+            }
+        }
+    }
+
+    return &TreeEnsemble{
+        features:  file.Features,
+        baseScore: file.BaseScore,
+        link:      link,
+        trees:     file.Trees,
+    }, nil
+}
+
+func (m *TreeEnsemble) Features() []string { return m.features }
+
+func (t Tree) eval(x []float64) float64 {
+    i := 0
+    for {
+        node := t.Nodes[i]
+        if node.Feature < 0 {
+            return node.Value
+        }
+        v := x[node.Feature]
+        if math.IsNaN(v) {
+            if node.MissingLeft {
+                i = node.Left
+            } else {
+                i = node.Right
+            }
+        } else if v < node.Threshold {
+            i = node.Left
+        } else {
+            i = node.Right
+        }
+    }
+}
+
+// Predict sums the tree outputs (boosting, logistic link) or averages them
+// (random forest, leaves hold probabilities)
+func (m *TreeEnsemble) Predict(features map[string]float64) float64 {
+    x := vector(m.features, features)
+    sum := 0.0
+    for _, tree := range m.trees {
+        sum += tree.eval(x)
+    }
+    if m.link == "average" {
+        return math.Max(0, math.Min(1, sum/float64(len(m.trees))))
+    }
+    return sigmoid(m.baseScore + sum)
+}
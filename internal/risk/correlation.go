@@ -0,0 +1,166 @@
+// File: internal/risk/correlation.go
+// ============================================
+package risk
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "math"
+    "time"
+)
+
+// KlineSource provides kline history for correlation checks (binance.Client)
+type KlineSource interface {
+    GetKlines(symbol, interval string, limit int) ([]types.Kline, error)
+}
+
+// barReturns returns close-to-close returns keyed by kline open time
+func barReturns(klines []types.Kline) map[int64]float64 {
+    returns := make(map[int64]float64, len(klines))
+    for i := 1; i < len(klines); i++ {
+        if klines[i-1].Close > 0 {
+            returns[klines[i].OpenTime.Unix()] = klines[i].Close/klines[i-1].Close - 1
+        }
+    }
+    return returns
+}
+
+// CalculateCorrelation - Pearson correlation of bar returns, matching bars by
+// open time. Returns 0 when fewer than 10 bars overlap.
+func CalculateCorrelation(a, b []types.Kline) float64 {
+    returnsA := barReturns(a)
+    returnsB := barReturns(b)
+
+    xs, ys := []float64{}, []float64{}
+    for t, x := range returnsA {
+        if y, ok := returnsB[t]; ok {
+            xs = append(xs, x)
+            ys = append(ys, y)
+        }
+    }
+    if len(xs) < 10 {
+        return 0
+    }
+
+    n := float64(len(xs))
+    meanX, meanY := 0.0, 0.0
+    for i := range xs {
+        meanX += xs[i]
+        meanY += ys[i]
+    }
+    meanX /= n
+    meanY /= n
+
+    covariance, varX, varY := 0.0, 0.0, 0.0
+    for i := range xs {
+        dx, dy := xs[i]-meanX, ys[i]-meanY
+        covariance += dx * dy
+        varX += dx * dx
+        varY += dy * dy
+    }
+    if varX == 0 || varY == 0 {
+        return 0
+    }
+    return covariance / math.Sqrt(varX*varY)
+}
+
+// CorrelationWithBook returns the highest return correlation between symbol
+// and any open position, and the position it was measured against
+func (m *Manager) CorrelationWithBook(symbol string, positions []types.Position) (float64, string, error) {
+    cfg := m.config.Risk.Correlation
+    timeframe := cfg.Timeframe
+    if timeframe == "" {
+        timeframe = "15m"
+    }
+    bars := cfg.Bars
+    if bars <= 0 {
+        bars = 96
+    }
+
+    candidate, err := m.klines.GetKlines(symbol, timeframe, bars+1)
+    if err != nil {
+        return 0, "", fmt.Errorf("failed to get %s klines: %v", symbol, err)
+    }
+
+    highest, with := 0.0, ""
+    for _, pos := range positions {
+        if pos.Symbol == symbol {
+            continue
+        }
+        held, err := m.klines.GetKlines(pos.Symbol, timeframe, bars+1)
+        if err != nil {
+            return 0, "", fmt.Errorf("failed to get %s klines: %v", pos.Symbol, err)
+        }
+        if corr := CalculateCorrelation(candidate, held); with == "" || corr > highest {
+            highest, with = corr, pos.Symbol
+        }
+    }
+
+    return highest, with, nil
+}
+
+// RecordAlert adds an alerted symbol to the correlation book. Alerts are
+// traded by hand, so the book holds every symbol alerted within
+// correlation.window_minutes - including earlier alerts of the same cycle.
+func (m *Manager) RecordAlert(symbol string) {
+    m.alerts[symbol] = time.Now()
+}
+
+// correlationBook returns the open positions plus the symbols alerted within
+// the window that aren't open positions, and drops expired alerts
+func (m *Manager) correlationBook(positions []types.Position) []types.Position {
+    window := time.Duration(m.config.Risk.Correlation.WindowMinutes * float64(time.Minute))
+    if window <= 0 {
+        window = 4 * time.Hour
+    }
+
+    book := append([]types.Position(nil), positions...)
+    held := make(map[string]bool, len(positions))
+    for _, pos := range positions {
+        held[pos.Symbol] = true
+    }
+    for symbol, alertedAt := range m.alerts {
+        switch {
+        case time.Since(alertedAt) >= window:
+            delete(m.alerts, symbol)
+        case !held[symbol]:
+            book = append(book, types.Position{Symbol: symbol})
+        }
+    }
+    return book
+}
+
+// checkCorrelation checks how correlated the candidate is with the open
+// positions and recent alerts. Returns the size multiplier to apply: 1 normally,
+// correlation.downsize_multiplier above downsize_above, and the candidate is
+// rejected above reject_above.
+func (m *Manager) checkCorrelation(symbol string, positions []types.Position) (bool, float64, string) {
+    cfg := m.config.Risk.Correlation
+    if !cfg.Enabled || m.klines == nil {
+        return true, 1.0, ""
+    }
+    book := m.correlationBook(positions)
+    if len(book) == 0 {
+        return true, 1.0, ""
+    }
+
+    corr, with, err := m.CorrelationWithBook(symbol, book)
+    if err != nil {
+        // Don't block alerts on a data hiccup, but say so
+        return true, 1.0, fmt.Sprintf("Correlation check skipped: %v", err)
+    }
+
+    if cfg.RejectAbove > 0 && corr >= cfg.RejectAbove {
+        return false, 0, fmt.Sprintf("Correlation %.2f with %s exceeds %.2f", corr, with, cfg.RejectAbove)
+    }
+
+    if cfg.DownsizeAbove > 0 && corr >= cfg.DownsizeAbove {
+        multiplier := cfg.DownsizeMultiplier
+        if multiplier <= 0 || multiplier > 1 {
+            multiplier = 0.5
+        }
+        return true, multiplier, fmt.Sprintf("Correlation %.2f with %s - size reduced to %.0f%%", corr, with, multiplier*100)
+    }
+
+    return true, 1.0, ""
+}
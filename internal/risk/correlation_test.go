@@ -0,0 +1,110 @@
+// File: internal/risk/correlation_test.go
+// ============================================
+package risk
+
+import (
+    "math"
+    "testing"
+    "time"
+
+    "binance-trading-bot/pkg/types"
+)
+
+// fakeKlines serves klines built from per-symbol return series
+type fakeKlines map[string][]float64
+
+func (f fakeKlines) GetKlines(symbol, interval string, limit int) ([]types.Kline, error) {
+    returns := f[symbol]
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    klines := []types.Kline{{OpenTime: start, Close: 100}}
+    for i, r := range returns {
+        klines = append(klines, types.Kline{
+            OpenTime: start.Add(time.Duration(i+1) * 15 * time.Minute),
+            Close:    klines[i].Close * (1 + r),
+        })
+    }
+    return klines, nil
+}
+
+// correlatedReturns returns a shared wave scaled per symbol plus a little
+// symbol-specific noise, and an unrelated series for the odd one out
+func correlatedReturns() fakeKlines {
+    source := fakeKlines{}
+    for s, symbol := range []string{"AAAUSDT", "BBBUSDT", "CCCUSDT"} {
+        returns := make([]float64, 96)
+        for i := range returns {
+            returns[i] = 0.01*math.Sin(float64(i)/3)*(1+0.2*float64(s)) + 0.0005*math.Cos(float64(i*(s+2)))
+        }
+        source[symbol] = returns
+    }
+    unrelated := make([]float64, 96)
+    for i := range unrelated {
+        unrelated[i] = 0.01 * math.Sin(float64(i*i)*1.7)
+    }
+    source["XYZUSDT"] = unrelated
+    return source
+}
+
+func correlationConfig(rejectAbove, downsizeAbove float64) *types.Config {
+    config := &types.Config{}
+    config.Strategy.MaxPositions = 5
+    config.Risk.Correlation.Enabled = true
+    config.Risk.Correlation.RejectAbove = rejectAbove
+    config.Risk.Correlation.DownsizeAbove = downsizeAbove
+    config.Risk.Correlation.DownsizeMultiplier = 0.5
+    config.Risk.Correlation.WindowMinutes = 60
+    return config
+}
+
+func TestCorrelatedAlertsInOneCycle(t *testing.T) {
+    tests := []struct {
+        name            string
+        config          *types.Config
+        wantAllowed     []bool
+        wantMultipliers []float64
+    }{
+        {"reject", correlationConfig(0.85, 0.65), []bool{true, false, false, true}, []float64{1, 0, 0, 1}},
+        {"downsize", correlationConfig(0, 0.65), []bool{true, true, true, true}, []float64{1, 0.5, 0.5, 1}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            m := NewManager(tt.config, 0, correlatedReturns())
+
+            // The alert loop: each alert joins the book before the next candidate
+            for i, symbol := range []string{"AAAUSDT", "BBBUSDT", "CCCUSDT", "XYZUSDT"} {
+                allowed, multiplier, note := m.CanOpenPosition(symbol, nil)
+                if allowed != tt.wantAllowed[i] || multiplier != tt.wantMultipliers[i] {
+                    t.Errorf("%s: allowed %v x%.2f (%s), want %v x%.2f", 
+                        symbol, allowed, multiplier, note, tt.wantAllowed[i], tt.wantMultipliers[i])
+                }
+                if allowed {
+                    m.RecordAlert(symbol)
+                }
+            }
+        })
+    }
+}
+
+func TestAlertsLeaveTheBookAfterTheWindow(t *testing.T) {
+    m := NewManager(correlationConfig(0.85, 0.65), 0, correlatedReturns())
+    m.RecordAlert("AAAUSDT")
+
+    if allowed, _, _ := m.CanOpenPosition("BBBUSDT", nil); allowed {
+        t.Fatal("BBBUSDT allowed while AAAUSDT is in the book")
+    }
+
+    m.alerts["AAAUSDT"] = time.Now().Add(-61 * time.Minute)
+    if allowed, multiplier, note := m.CanOpenPosition("BBBUSDT", nil); !allowed || multiplier != 1 {
+        t.Errorf("BBBUSDT after the window: allowed %v x%.2f (%s), want a full size", allowed, multiplier, note)
+    }
+    if len(m.alerts) != 0 {
+        t.Errorf("expired alert still in the book: %v", m.alerts)
+    }
+
+    // Open positions stay in the book whatever their age
+    positions := []types.Position{{Symbol: "AAAUSDT", EntryTime: time.Now().Add(-48 * time.Hour)}}
+    if allowed, _, _ := m.CanOpenPosition("CCCUSDT", positions); allowed {
+        t.Error("CCCUSDT allowed next to an open AAAUSDT position")
+    }
+}
@@ -0,0 +1,198 @@
+// File: internal/risk/equity.go
+// ============================================
+package risk
+
+import (
+    "binance-trading-bot/pkg/types"
+    "encoding/json"
+    "fmt"
+    "log"
+    "os"
+    "strings"
+    "time"
+)
+
+// EquityPoint is one sample of the equity curve
+type EquityPoint struct {
+    Time   time.Time
+    Equity float64
+}
+
+// EquityUpdate reports the drawdown after an equity sample and which limits
+// were crossed by it
+type EquityUpdate struct {
+    Equity          float64
+    Peak            float64
+    DrawdownPercent float64
+    HitMax          bool // Crossed max_drawdown_percent this update
+    HitFlatten      bool // Crossed flatten_drawdown_percent this update
+    Recovered       bool // Back under max_drawdown_percent this update
+    PeakReset       bool // Peak moved down to equity after drawdown_reset_hours
+}
+
+// equityState is what survives a restart, so the drawdown guard doesn't
+// start over from whatever equity is left
+type equityState struct {
+    PeakEquity   float64   `json:"peak_equity"`
+    PeakTime     time.Time `json:"peak_time"`
+    BlockedSince time.Time `json:"blocked_since"` // Zero while entries are allowed
+}
+
+func loadEquityState(path string) (equityState, error) {
+    var state equityState
+    data, err := os.ReadFile(path)
+    if os.IsNotExist(err) {
+        return state, nil
+    }
+    if err != nil {
+        return state, fmt.Errorf("failed to read equity state %s: %v", path, err)
+    }
+    if err := json.Unmarshal(data, &state); err != nil {
+        return state, fmt.Errorf("equity state %s: %v", path, err)
+    }
+    return state, nil
+}
+
+// saveEquityState writes through a temp file so a crash can't leave half a file
+func saveEquityState(path string, state equityState) error {
+    data, err := json.Marshal(state)
+    if err != nil {
+        return err
+    }
+    tmp := path + ".tmp"
+    if err := os.WriteFile(tmp, data, 0644); err != nil {
+        return fmt.Errorf("failed to write equity state %s: %v", path, err)
+    }
+    return os.Rename(tmp, path)
+}
+
+// maxEquityPoints bounds the equity curve (24h of 30s cycles)
+const maxEquityPoints = 2880
+
+// MarkToMarket values the account in the notional asset: every balance at
+// its current price, plus the unrealized PnL of tracked setups whose coins
+// are not in the balances - as if each alert had been taken from the free
+// balance. Assets without a price against the notional asset are left out.
+func MarkToMarket(balances map[string]float64, positions []types.Position, prices map[string]float64, notional string) float64 {
+    value := func(asset string, amount float64) float64 {
+        switch {
+        case asset == notional:
+            return amount
+        case prices[asset+notional] > 0:
+            return amount * prices[asset+notional]
+        case prices[notional+asset] > 0:
+            return amount / prices[notional+asset]
+        }
+        return 0
+    }
+
+    equity := 0.0
+    for asset, amount := range balances {
+        equity += value(asset, amount)
+    }
+    for _, pos := range positions {
+        base := strings.TrimSuffix(pos.Symbol, notional)
+        if _, held := balances[base]; held {
+            continue // Taken in this account - the balance values it
+        }
+        price := prices[pos.Symbol]
+        if price <= 0 {
+            price = pos.CurrentPrice
+        }
+        rate := pos.NotionalRate
+        if rate <= 0 {
+            rate = 1
+        }
+        equity += pos.Quantity * (price - pos.EntryPrice) * rate
+    }
+    return equity
+}
+
+// UpdateEquity records an equity sample, moves the peak and returns the
+// current drawdown. Blocked entries clear once equity is back within
+// max_drawdown_percent of the peak, or after drawdown_reset_hours when the
+// peak is reset to the current equity.
+func (m *Manager) UpdateEquity(equity float64) EquityUpdate {
+    now := time.Now()
+    m.equityCurve = append(m.equityCurve, EquityPoint{Time: now, Equity: equity})
+    if len(m.equityCurve) > maxEquityPoints {
+        m.equityCurve = m.equityCurve[len(m.equityCurve)-maxEquityPoints:]
+    }
+    changed := false
+    if equity > m.peakEquity {
+        m.peakEquity, m.peakTime = equity, now
+        changed = true
+    }
+
+    wasBlocked, wasFlatten := m.drawdownBlocked(), m.flattenRequired()
+    m.equity = equity
+
+    update := EquityUpdate{}
+    update.HitMax = !wasBlocked && m.drawdownBlocked()
+    update.HitFlatten = !wasFlatten && m.flattenRequired()
+    update.Recovered = wasBlocked && !m.drawdownBlocked()
+
+    blocked := m.drawdownBlocked() || m.flattenRequired()
+    switch {
+    case blocked && m.blockedSince.IsZero():
+        m.blockedSince = now
+        changed = true
+    case !blocked && !m.blockedSince.IsZero():
+        m.blockedSince = time.Time{}
+        changed = true
+    }
+
+    resetAfter := time.Duration(m.config.Risk.DrawdownResetHours * float64(time.Hour))
+    if blocked && resetAfter > 0 && now.Sub(m.blockedSince) >= resetAfter {
+        m.peakEquity, m.peakTime, m.blockedSince = equity, now, time.Time{}
+        update.PeakReset, update.Recovered = true, true
+        changed = true
+    }
+
+    update.Equity = equity
+    update.Peak = m.peakEquity
+    update.DrawdownPercent = m.DrawdownPercent()
+
+    if changed && m.equityPath != "" {
+        state := equityState{PeakEquity: m.peakEquity, PeakTime: m.peakTime, BlockedSince: m.blockedSince}
+        if err := saveEquityState(m.equityPath, state); err != nil {
+            log.Printf("⚠️  Failed to persist peak equity: %v", err)
+        }
+    }
+    return update
+}
+
+// DrawdownPercent returns how far equity is below its peak, 0 before the
+// first sample
+func (m *Manager) DrawdownPercent() float64 {
+    if m.peakEquity <= 0 || m.equity <= 0 {
+        return 0
+    }
+    return (m.peakEquity - m.equity) / m.peakEquity * 100
+}
+
+// drawdownBlocked reports whether max_drawdown_percent blocks new entries
+func (m *Manager) drawdownBlocked() bool {
+    return m.config.Risk.MaxDrawdown > 0 && m.DrawdownPercent() >= m.config.Risk.MaxDrawdown
+}
+
+// flattenRequired reports whether flatten_drawdown_percent has been reached
+func (m *Manager) flattenRequired() bool {
+    return m.config.Risk.FlattenDrawdown > 0 && m.DrawdownPercent() >= m.config.Risk.FlattenDrawdown
+}
+
+// ShouldFlatten reports whether every tracked setup should be exited
+func (m *Manager) ShouldFlatten() bool {
+    return m.flattenRequired()
+}
+
+// GetEquity returns the last equity sample and the peak, which is carried
+// across restarts when the equity state is persisted
+func (m *Manager) GetEquity() (equity, peak float64) {
+    return m.equity, m.peakEquity
+}
+
+// GetEquityCurve returns a copy of the recorded equity samples
+func (m *Manager) GetEquityCurve() []EquityPoint {
+    return append([]EquityPoint(nil), m.equityCurve...)
+}
@@ -0,0 +1,95 @@
+// File: internal/risk/equity_test.go
+// ============================================
+package risk
+
+import (
+    "math"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+
+    "binance-trading-bot/pkg/types"
+)
+
+func drawdownConfig(t *testing.T) *types.Config {
+    config := &types.Config{}
+    config.Strategy.MaxPositions = 5
+    config.Risk.MaxDrawdown = 10
+    config.Risk.EquityStatePath = filepath.Join(t.TempDir(), "equity_state.json")
+    return config
+}
+
+func TestPeakEquitySurvivesRestart(t *testing.T) {
+    config := drawdownConfig(t)
+
+    first := NewManager(config, 0, nil)
+    first.UpdateEquity(1000)
+    if update := first.UpdateEquity(850); !update.HitMax {
+        t.Fatalf("expected max drawdown at 15%%, got %+v", update)
+    }
+
+    restarted := NewManager(config, 0, nil)
+    if _, peak := restarted.GetEquity(); peak != 1000 {
+        t.Fatalf("peak after restart = %.2f, want 1000", peak)
+    }
+    update := restarted.UpdateEquity(850)
+    if update.Peak != 1000 || update.DrawdownPercent < 14.99 {
+        t.Errorf("update after restart = %+v, want a 15%% drawdown from 1000", update)
+    }
+    if ok, _, reason := restarted.CanOpenPosition("", nil); ok || !strings.Contains(reason, "drawdown") {
+        t.Errorf("CanOpenPosition after restart = %v %q, want a drawdown block", ok, reason)
+    }
+}
+
+func TestDrawdownBlockClears(t *testing.T) {
+    t.Run("recovery", func(t *testing.T) {
+        m := NewManager(drawdownConfig(t), 0, nil)
+        m.UpdateEquity(1000)
+        m.UpdateEquity(850)
+        if update := m.UpdateEquity(950); !update.Recovered || update.PeakReset {
+            t.Errorf("update = %+v, want recovered without a reset", update)
+        }
+    })
+
+    t.Run("reset after cooldown", func(t *testing.T) {
+        config := drawdownConfig(t)
+        config.Risk.DrawdownResetHours = 24
+        m := NewManager(config, 0, nil)
+        m.UpdateEquity(1000)
+        m.UpdateEquity(850)
+
+        if update := m.UpdateEquity(840); update.PeakReset {
+            t.Fatal("peak reset before the cooldown elapsed")
+        }
+        m.blockedSince = time.Now().Add(-25 * time.Hour)
+        update := m.UpdateEquity(840)
+        if !update.PeakReset || !update.Recovered || update.Peak != 840 || update.DrawdownPercent != 0 {
+            t.Errorf("update = %+v, want the peak reset to 840", update)
+        }
+
+        restarted := NewManager(config, 0, nil)
+        if _, peak := restarted.GetEquity(); peak != 840 {
+            t.Errorf("persisted peak = %.2f, want 840", peak)
+        }
+    })
+}
+
+func TestMarkToMarketTrackedSetups(t *testing.T) {
+    balances := map[string]float64{"USDT": 1000, "ETH": 0.5}
+    prices := map[string]float64{"ETHUSDT": 2000, "SOLUSDT": 110, "ABCBTC": 0.0012, "BTCUSDT": 50000}
+    positions := []types.Position{
+        {Symbol: "SOLUSDT", EntryPrice: 100, Quantity: 2},                           // +20 unrealized
+        {Symbol: "ETHUSDT", EntryPrice: 1800, Quantity: 0.5},                        // Held - the balance values it
+        {Symbol: "ABCBTC", EntryPrice: 0.001, Quantity: 100, NotionalRate: 50000},   // +0.02 BTC = +1000
+        {Symbol: "XYZUSDT", EntryPrice: 10, CurrentPrice: 9, Quantity: 5},           // No ticker price: -5
+    }
+
+    // 1000 USDT + 0.5 ETH at 2000 + 20 + 1000 - 5
+    if equity := MarkToMarket(balances, positions, prices, "USDT"); math.Abs(equity-3015) > 1e-6 {
+        t.Errorf("equity = %.4f, want 3015", equity)
+    }
+    if equity := MarkToMarket(balances, nil, prices, "USDT"); equity != 2000 {
+        t.Errorf("equity without setups = %.4f, want 2000", equity)
+    }
+}
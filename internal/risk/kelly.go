@@ -0,0 +1,217 @@
+// File: internal/risk/kelly.go
+// ============================================
+package risk
+
+import (
+    "binance-trading-bot/pkg/types"
+    "bufio"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "math"
+    "os"
+    "time"
+)
+
+// TradeRecord is a closed trade as persisted in the trade history file
+type TradeRecord struct {
+    Time       time.Time `json:"time"`
+    Symbol     string    `json:"symbol"`
+    Strategy   string    `json:"strategy"`
+    PnL        float64   `json:"pnl"`
+    PnLPercent float64   `json:"pnl_percent"`
+    Duration   float64   `json:"duration_minutes"`
+}
+
+// KellyEstimate is the Kelly sizing derived from one strategy's trades
+type KellyEstimate struct {
+    Strategy   string
+    Trades     int
+    WinRate    float64
+    AvgWin     float64 // Average winning return (%)
+    AvgLoss    float64 // Average losing return (%), positive
+    FullKelly  float64 // W - (1-W)/R with R = AvgWin/AvgLoss
+    Multiplier float64 // Share of full Kelly applied
+    Fraction   float64 // Equity fraction after the multiplier and caps
+}
+
+// ErrKellySamples means a strategy has too few trades for a Kelly estimate
+var ErrKellySamples = errors.New("not enough trades for a Kelly estimate")
+
+// ErrKellyNoEdge means a strategy's trades show no positive expectancy
+var ErrKellyNoEdge = errors.New("no edge")
+
+// maxTradeRecords bounds the trade history kept in memory
+const maxTradeRecords = 10000
+
+// LoadTradeHistory reads a JSON lines trade history. A missing file is an
+// empty history.
+func LoadTradeHistory(path string) ([]TradeRecord, error) {
+    file, err := os.Open(path)
+    if os.IsNotExist(err) {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, fmt.Errorf("failed to open trade history %s: %v", path, err)
+    }
+    defer file.Close()
+
+    records := []TradeRecord{}
+    scanner := bufio.NewScanner(file)
+    for line := 1; scanner.Scan(); line++ {
+        if len(scanner.Bytes()) == 0 {
+            continue
+        }
+        var record TradeRecord
+        if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+            return nil, fmt.Errorf("trade history %s line %d: %v", path, line, err)
+        }
+        records = append(records, record)
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, fmt.Errorf("failed to read trade history %s: %v", path, err)
+    }
+    if len(records) > maxTradeRecords {
+        records = records[len(records)-maxTradeRecords:]
+    }
+    return records, nil
+}
+
+// appendTradeRecord appends one record to the trade history file
+func appendTradeRecord(path string, record TradeRecord) error {
+    line, err := json.Marshal(record)
+    if err != nil {
+        return err
+    }
+    file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+    if err != nil {
+        return fmt.Errorf("failed to open trade history %s: %v", path, err)
+    }
+    defer file.Close()
+    _, err = file.Write(append(line, '\n'))
+    return err
+}
+
+// EstimateKelly computes the Kelly fraction from trade returns and scales it
+// by fraction, clamped to [minFraction, maxFraction]. The floor only applies
+// to a positive edge: a full Kelly at or below 0 leaves Fraction 0. A history
+// of only wins sizes at maxFraction; one of only losses has no edge.
+func EstimateKelly(trades []TradeRecord, fraction, minFraction, maxFraction float64) KellyEstimate {
+    estimate := KellyEstimate{Trades: len(trades), Multiplier: fraction}
+    wins, losses := 0, 0
+    totalWin, totalLoss := 0.0, 0.0
+    for _, trade := range trades {
+        if trade.PnLPercent > 0 {
+            wins++
+            totalWin += trade.PnLPercent
+        } else {
+            losses++
+            totalLoss += math.Abs(trade.PnLPercent)
+        }
+    }
+    if len(trades) == 0 {
+        return estimate
+    }
+
+    estimate.WinRate = float64(wins) / float64(len(trades))
+    if wins > 0 {
+        estimate.AvgWin = totalWin / float64(wins)
+    }
+    if losses > 0 {
+        estimate.AvgLoss = totalLoss / float64(losses)
+    }
+
+    switch {
+    case wins == 0:
+        estimate.FullKelly = -1 // Nothing but losses
+        return estimate
+    case losses == 0:
+        estimate.FullKelly = 1 // Nothing but wins
+        estimate.Fraction = maxFraction
+        return estimate
+    case totalLoss == 0:
+        estimate.FullKelly = estimate.WinRate // Losses all break even: R is unbounded
+    default:
+        estimate.FullKelly = estimate.WinRate - (1-estimate.WinRate)/(estimate.AvgWin/estimate.AvgLoss)
+    }
+    if estimate.FullKelly <= 0 {
+        return estimate
+    }
+
+    estimate.Fraction = math.Max(estimate.FullKelly*fraction, minFraction)
+    if maxFraction > 0 {
+        estimate.Fraction = math.Min(estimate.Fraction, maxFraction)
+    }
+    return estimate
+}
+
+// kellySettings returns the Kelly config with defaults filled in
+func (m *Manager) kellySettings() (fraction, minFraction, maxFraction float64, minTrades, maxTrades int) {
+    cfg := m.config.Risk.PositionSizing.Kelly
+    fraction, minFraction, maxFraction = cfg.Fraction, cfg.MinFraction, cfg.MaxFraction
+    minTrades, maxTrades = cfg.MinTrades, cfg.MaxTrades
+    if fraction <= 0 {
+        fraction = 0.25
+    }
+    if maxFraction <= 0 {
+        maxFraction = 0.5
+    }
+    if minTrades <= 0 {
+        minTrades = 20
+    }
+    if maxTrades <= 0 {
+        maxTrades = 500
+    }
+    return
+}
+
+// KellyEstimate estimates Kelly from the most recent persisted trades of a strategy
+func (m *Manager) KellyEstimate(strategy string) KellyEstimate {
+    fraction, minFraction, maxFraction, _, maxTrades := m.kellySettings()
+
+    trades := []TradeRecord{}
+    for i := len(m.trades) - 1; i >= 0 && len(trades) < maxTrades; i-- {
+        if m.trades[i].Strategy == strategy {
+            trades = append(trades, m.trades[i])
+        }
+    }
+    estimate := EstimateKelly(trades, fraction, minFraction, maxFraction)
+    estimate.Strategy = strategy
+    return estimate
+}
+
+// CalculateKellySize sizes a BUY at the strategy's Kelly fraction of equity
+// (times multiplier), capped like CalculateRiskSize. Returns ErrKellySamples
+// below min_trades and ErrKellyNoEdge when the strategy shows no edge.
+func (m *Manager) CalculateKellySize(strategy string, entry, stopLoss, multiplier, available, notionalRate float64, info types.SymbolInfo) (RiskSize, KellyEstimate, error) {
+    _, _, _, minTrades, _ := m.kellySettings()
+    if notionalRate <= 0 {
+        notionalRate = 1
+    }
+
+    estimate := m.KellyEstimate(strategy)
+    size := RiskSize{Equity: m.sizingEquity()}
+    if estimate.Trades < minTrades {
+        return size, estimate, fmt.Errorf("%w (%s: %d of %d)", ErrKellySamples, strategy, estimate.Trades, minTrades)
+    }
+    if estimate.Fraction <= 0 {
+        return size, estimate, fmt.Errorf("%w for %s (full Kelly %.3f)", ErrKellyNoEdge, strategy, estimate.FullKelly)
+    }
+    if size.Equity <= 0 {
+        return size, estimate, fmt.Errorf("no equity to size from")
+    }
+
+    quantity := size.Equity * estimate.Fraction * multiplier / (entry * notionalRate)
+    quantity, err := m.fitQuantity(quantity, entry, available, notionalRate, info, &size.Caps)
+    if err != nil {
+        return size, estimate, err
+    }
+
+    size.Quantity = quantity
+    size.Notional = quantity * entry * notionalRate
+    if stopLoss > 0 && stopLoss < entry {
+        size.StopDistance = (entry - stopLoss) / entry * 100
+        size.RiskAmount = quantity * (entry - stopLoss) * notionalRate
+    }
+    return size, estimate, nil
+}
@@ -0,0 +1,44 @@
+// File: internal/risk/kelly_test.go
+// ============================================
+package risk
+
+import (
+    "math"
+    "testing"
+)
+
+func trades(returns ...float64) []TradeRecord {
+    records := make([]TradeRecord, len(returns))
+    for i, r := range returns {
+        records[i] = TradeRecord{Strategy: "momentum", PnLPercent: r}
+    }
+    return records
+}
+
+func TestEstimateKelly(t *testing.T) {
+    tests := []struct {
+        name          string
+        returns       []float64
+        minFraction   float64
+        wantFullKelly float64
+        wantFraction  float64
+    }{
+        // W 2/3, R 4/2: 2/3 - (1/3)/2 = 0.5, quarter Kelly 0.125
+        {"positive edge", []float64{4, 4, -2, 4, 4, -2}, 0, 0.5, 0.125},
+        {"floor lifts a positive edge", []float64{4, 4, -2, 4, 4, -2}, 0.2, 0.5, 0.2},
+        // W 1/3, R 1: 1/3 - 2/3 = -1/3 - the floor must not size it
+        {"negative edge ignores floor", []float64{2, -2, -2}, 0.2, -1.0 / 3, 0},
+        {"all wins capped at max", []float64{1, 2, 3}, 0, 1, 0.5},
+        {"all losses have no edge", []float64{-1, -2, -3}, 0.2, -1, 0},
+        {"no trades", nil, 0.2, 0, 0},
+    }
+    for _, tt := range tests {
+        got := EstimateKelly(trades(tt.returns...), 0.25, tt.minFraction, 0.5)
+        if math.Abs(got.FullKelly-tt.wantFullKelly) > 1e-9 {
+            t.Errorf("%s: FullKelly = %v, want %v", tt.name, got.FullKelly, tt.wantFullKelly)
+        }
+        if math.Abs(got.Fraction-tt.wantFraction) > 1e-9 {
+            t.Errorf("%s: Fraction = %v, want %v", tt.name, got.Fraction, tt.wantFraction)
+        }
+    }
+}
@@ -5,7 +5,10 @@ package risk
 import (
     "binance-trading-bot/pkg/types"
     "fmt"
+    "log"
     "math"
+    "path/filepath"
+    "strings"
     "time"
 )
 
@@ -14,6 +17,22 @@ type Manager struct {
     dailyPnL       float64
     initialBalance float64
     tradeHistory   []TradeResult
+    klines         KlineSource
+    market         *types.MarketState
+    alerts         map[string]time.Time // Recently alerted symbols, for the correlation book
+    
+    // Equity curve for drawdown limits
+    equity         float64
+    peakEquity     float64
+    peakTime       time.Time
+    blockedSince   time.Time
+    equityCurve    []EquityPoint
+    equityPath     string
+    
+    // Persisted trade history for per-strategy Kelly estimates
+    trades         []TradeRecord
+    historyPath    string
+    setupsPath     string // Tracked alert setups, so restarts don't lose their outcomes
 }
 
 type TradeResult struct {
@@ -23,16 +42,71 @@ type TradeResult struct {
     Success   bool
 }
 
-func NewManager(config *types.Config, initialBalance float64) *Manager {
-    return &Manager{
+// NewManager creates the risk manager. klines may be nil, which disables the
+// correlation filter. The trade history file is loaded when configured, or by
+// default in kelly sizing mode; tracked setups are kept next to it.
+func NewManager(config *types.Config, initialBalance float64, klines KlineSource) *Manager {
+    m := &Manager{
         config:         config,
         dailyPnL:       0,
         initialBalance: initialBalance,
         tradeHistory:   make([]TradeResult, 0),
+        klines:         klines,
+        alerts:         make(map[string]time.Time),
+        historyPath:    config.Risk.PositionSizing.Kelly.HistoryPath,
     }
+    if m.historyPath == "" && config.Risk.PositionSizing.Mode == "kelly" {
+        m.historyPath = "trade_history.jsonl"
+    }
+    
+    if m.historyPath != "" {
+        trades, err := LoadTradeHistory(m.historyPath)
+        if err != nil {
+            log.Printf("⚠️  %v - starting with an empty trade history", err)
+        } else {
+            m.trades = trades
+            log.Printf("📒 Loaded %d trades from %s", len(trades), m.historyPath)
+        }
+    }
+    
+    m.setupsPath = config.Risk.SetupsPath
+    if m.setupsPath == "" && m.historyPath != "" {
+        m.setupsPath = filepath.Join(filepath.Dir(m.historyPath), "tracked_setups.json")
+    }
+    
+    m.equityPath = config.Risk.EquityStatePath
+    if m.equityPath == "" && (config.Risk.MaxDrawdown > 0 || config.Risk.FlattenDrawdown > 0) {
+        m.equityPath = filepath.Join(filepath.Dir(m.historyPath), "equity_state.json")
+    }
+    if m.equityPath != "" {
+        state, err := loadEquityState(m.equityPath)
+        if err != nil {
+            log.Printf("⚠️  %v - peak equity starts from the first sample", err)
+        } else if state.PeakEquity > 0 {
+            m.peakEquity, m.peakTime, m.blockedSince = state.PeakEquity, state.PeakTime, state.BlockedSince
+            log.Printf("📈 Loaded peak equity %.2f (%s) from %s",
+                state.PeakEquity, state.PeakTime.Format("2006-01-02 15:04"), m.equityPath)
+        }
+    }
+    return m
 }
 
-func (m *Manager) CanOpenPosition(positions []types.Position) (bool, string) {
+// CanOpenPosition checks the account limits and, when a symbol is given, its
+// correlation with the open positions and recent alerts. Returns the size multiplier to apply:
+// 1 normally, less when the candidate is downsized for correlation. An empty
+// symbol checks only the account limits, before candidates are analyzed.
+func (m *Manager) CanOpenPosition(symbol string, positions []types.Position) (bool, float64, string) {
+    if ok, reason := m.accountAllows(positions); !ok {
+        return false, 0, reason
+    }
+    if symbol == "" {
+        return true, 1.0, ""
+    }
+    return m.checkCorrelation(symbol, positions)
+}
+
+// accountAllows applies the limits that don't depend on the candidate
+func (m *Manager) accountAllows(positions []types.Position) (bool, string) {
     if len(positions) >= m.config.Strategy.MaxPositions {
         return false, "Maximum positions reached"
     }
@@ -41,6 +115,14 @@ func (m *Manager) CanOpenPosition(positions []types.Position) (bool, string) {
         return false, fmt.Sprintf("Daily loss limit reached: %.2f USDT", m.dailyPnL)
     }
     
+    if m.drawdownBlocked() {
+        return false, fmt.Sprintf("Max drawdown reached: %.2f%% below peak equity %.2f", m.DrawdownPercent(), m.peakEquity)
+    }
+    
+    if m.config.Risk.BlockRiskOff && m.market != nil && m.market.RiskOff {
+        return false, fmt.Sprintf("Risk-off market: %s", strings.Join(m.market.RiskOffReasons, ", "))
+    }
+    
     // NEW: Check win rate - if losing streak, reduce position size or stop
     if len(m.tradeHistory) >= 5 {
         recentTrades := m.tradeHistory[len(m.tradeHistory)-5:]
@@ -205,6 +287,64 @@ func (m *Manager) CalculateTakeProfitSimple(entryPrice float64, side string) flo
     return m.CalculateTakeProfit(entryPrice, side, 0.7)
 }
 
+// CalculateStopLossWithLevels places the stop just beyond the support (BUY) or
+// resistance (SELL) closest to the ATR/percentage stop, when snapping is enabled
+// and such a level lies within max_distance_percent of it
+func (m *Manager) CalculateStopLossWithLevels(entryPrice float64, side string, atr float64, levels []types.PriceLevel) float64 {
+    stopLoss := m.CalculateStopLoss(entryPrice, side, atr)
+    
+    buffer := m.config.Risk.SnapToLevels.BufferPercent / 100.0
+    if side == "BUY" {
+        return m.snapToLevel(entryPrice, stopLoss, levels, true, 1-buffer)
+    }
+    return m.snapToLevel(entryPrice, stopLoss, levels, false, 1+buffer)
+}
+
+// CalculateTakeProfitWithLevels places the target just before the resistance
+// (BUY) or support (SELL) closest to the percentage target, when snapping is
+// enabled and such a level lies within max_distance_percent of it
+func (m *Manager) CalculateTakeProfitWithLevels(entryPrice float64, side string, signalStrength float64, levels []types.PriceLevel) float64 {
+    takeProfit := m.CalculateTakeProfit(entryPrice, side, signalStrength)
+    
+    buffer := m.config.Risk.SnapToLevels.BufferPercent / 100.0
+    if side == "BUY" {
+        return m.snapToLevel(entryPrice, takeProfit, levels, false, 1-buffer)
+    }
+    return m.snapToLevel(entryPrice, takeProfit, levels, true, 1+buffer)
+}
+
+// snapToLevel moves price to the level on the given side of entry whose
+// buffered price is closest to it. Returns price unchanged if none qualifies.
+func (m *Manager) snapToLevel(entryPrice, price float64, levels []types.PriceLevel, below bool, bufferFactor float64) float64 {
+    snap := m.config.Risk.SnapToLevels
+    if !snap.Enabled || len(levels) == 0 || entryPrice <= 0 {
+        return price
+    }
+    
+    maxDistance := entryPrice * snap.MaxDistancePercent / 100.0
+    best := price
+    bestDistance := math.Inf(1)
+    
+    for _, level := range levels {
+        if level.Touches < snap.MinTouches {
+            continue
+        }
+        
+        snapped := level.Price * bufferFactor
+        if (below && snapped >= entryPrice) || (!below && snapped <= entryPrice) {
+            continue
+        }
+        
+        distance := math.Abs(snapped - price)
+        if distance <= maxDistance && distance < bestDistance {
+            best = snapped
+            bestDistance = distance
+        }
+    }
+    
+    return best
+}
+
 func (m *Manager) UpdateTrailingStop(position *types.Position) bool {
     if !m.config.Strategy.TrailingStopEnabled || !position.TrailingStopEnabled {
         return false
@@ -275,6 +415,11 @@ func (m *Manager) ShouldClosePosition(position types.Position) (bool, string) {
     return false, ""
 }
 
+// SetMarketState stores the market state computed this cycle
+func (m *Manager) SetMarketState(state types.MarketState) {
+    m.market = &state
+}
+
 func (m *Manager) UpdateDailyPnL(pnl float64) {
     m.dailyPnL += pnl
 }
@@ -287,8 +432,27 @@ func (m *Manager) ResetDailyPnL() {
     m.dailyPnL = 0
 }
 
-// NEW: Record trade results for performance tracking
-func (m *Manager) RecordTrade(symbol string, pnl float64, duration float64) {
+// NEW: Record trade results for performance tracking. The trade is also
+// appended to the persisted history when one is configured.
+func (m *Manager) RecordTrade(symbol, strategy string, pnl, pnlPercent, duration float64) {
+    if m.historyPath != "" {
+        record := TradeRecord{
+            Time:       time.Now(),
+            Symbol:     symbol,
+            Strategy:   strategy,
+            PnL:        pnl,
+            PnLPercent: pnlPercent,
+            Duration:   duration,
+        }
+        m.trades = append(m.trades, record)
+        if len(m.trades) > maxTradeRecords {
+            m.trades = m.trades[len(m.trades)-maxTradeRecords:]
+        }
+        if err := appendTradeRecord(m.historyPath, record); err != nil {
+            log.Printf("⚠️  Failed to persist trade: %v", err)
+        }
+    }
+    
     result := TradeResult{
         Symbol:   symbol,
         PnL:      pnl,
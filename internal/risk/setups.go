@@ -0,0 +1,54 @@
+// File: internal/risk/setups.go
+// ============================================
+package risk
+
+import (
+    "binance-trading-bot/pkg/types"
+    "encoding/json"
+    "log"
+    "os"
+)
+
+// LoadSetups returns the tracked alert setups saved before a restart, so
+// their exits are still alerted and their outcomes still reach the trade
+// history. Nothing is loaded when persistence is off.
+func (m *Manager) LoadSetups() []types.Position {
+    positions := make([]types.Position, 0)
+    if m.setupsPath == "" {
+        return positions
+    }
+
+    data, err := os.ReadFile(m.setupsPath)
+    if os.IsNotExist(err) {
+        return positions
+    }
+    if err == nil {
+        err = json.Unmarshal(data, &positions)
+    }
+    if err != nil {
+        log.Printf("⚠️  Tracked setups %s: %v - starting without tracked setups", m.setupsPath, err)
+        return make([]types.Position, 0)
+    }
+    if len(positions) > 0 {
+        log.Printf("📌 Loaded %d tracked setups from %s", len(positions), m.setupsPath)
+    }
+    return positions
+}
+
+// SaveSetups persists the tracked alert setups, through a temp file so a
+// crash can't leave half a file
+func (m *Manager) SaveSetups(positions []types.Position) {
+    if m.setupsPath == "" {
+        return
+    }
+    data, err := json.Marshal(positions)
+    if err == nil {
+        tmp := m.setupsPath + ".tmp"
+        if err = os.WriteFile(tmp, data, 0644); err == nil {
+            err = os.Rename(tmp, m.setupsPath)
+        }
+    }
+    if err != nil {
+        log.Printf("⚠️  Failed to persist tracked setups to %s: %v", m.setupsPath, err)
+    }
+}
@@ -0,0 +1,70 @@
+// File: internal/risk/setups_test.go
+// ============================================
+package risk
+
+import (
+    "path/filepath"
+    "testing"
+    "time"
+
+    "binance-trading-bot/pkg/types"
+)
+
+func kellyConfig(t *testing.T) *types.Config {
+    config := &types.Config{}
+    config.Strategy.MaxPositions = 3
+    config.Risk.PositionSizing.Mode = "kelly"
+    config.Risk.PositionSizing.Kelly.HistoryPath = filepath.Join(t.TempDir(), "trade_history.jsonl")
+    return config
+}
+
+func TestTrackedSetupsSurviveRestart(t *testing.T) {
+    config := kellyConfig(t)
+    entry := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+    first := NewManager(config, 0, nil)
+    if setups := first.LoadSetups(); len(setups) != 0 {
+        t.Fatalf("setups before any alert = %v", setups)
+    }
+    first.SaveSetups([]types.Position{{
+        Symbol: "SOLUSDT", EntryPrice: 100, Quantity: 2, Side: "BUY", StopLoss: 97, TakeProfit: 106,
+        TrailingStopEnabled: true, TrailingStopPrice: 101.5, Strategy: "new_listing", NotionalRate: 1, EntryTime: entry,
+    }})
+
+    setups := NewManager(config, 0, nil).LoadSetups()
+    if len(setups) != 1 {
+        t.Fatalf("loaded %d setups, want 1", len(setups))
+    }
+    got := setups[0]
+    if got.Symbol != "SOLUSDT" || got.StopLoss != 97 || got.TrailingStopPrice != 101.5 || 
+        got.Strategy != "new_listing" || !got.EntryTime.Equal(entry) {
+        t.Errorf("loaded setup = %+v", got)
+    }
+    if filepath.Dir(config.Risk.PositionSizing.Kelly.HistoryPath) != filepath.Dir(first.setupsPath) {
+        t.Errorf("setups at %s, want them next to the trade history", first.setupsPath)
+    }
+}
+
+func TestSetupOutcomesFeedKelly(t *testing.T) {
+    config := kellyConfig(t)
+    m := NewManager(config, 0, nil)
+
+    // Exits of tracked setups: 12 targets at +6%, 8 stops at -3%
+    for i := 0; i < 20; i++ {
+        pnlPercent := 6.0
+        if i%5 >= 3 {
+            pnlPercent = -3
+        }
+        m.RecordTrade("SOLUSDT", "momentum", pnlPercent, pnlPercent, 90)
+    }
+    m.RecordTrade("NEWUSDT", "new_listing", 4, 4, 30)
+
+    restarted := NewManager(config, 0, nil)
+    estimate := restarted.KellyEstimate("momentum")
+    if estimate.Trades != 20 || estimate.WinRate != 0.6 || estimate.Fraction <= 0 {
+        t.Errorf("momentum estimate after restart = %+v, want 20 trades at a 60%% win rate with an edge", estimate)
+    }
+    if listing := restarted.KellyEstimate("new_listing"); listing.Trades != 1 {
+        t.Errorf("new_listing trades = %d, want 1", listing.Trades)
+    }
+}
@@ -0,0 +1,104 @@
+// File: internal/risk/sizing.go
+// ============================================
+package risk
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "math"
+)
+
+// RiskSize is a position sized from equity (risk_percent or Kelly mode)
+type RiskSize struct {
+    Quantity     float64
+    Notional     float64  // Position value in the notional asset
+    RiskAmount   float64  // Loss in the notional asset if the stop is hit
+    Equity       float64  // Equity the risk was taken from
+    StopDistance float64  // Percent from entry to stop
+    Caps         []string // Limits that cut the size below the risk target
+}
+
+// CalculateRiskSize sizes a BUY so that hitting the stop loses risk_percent
+// of equity (times multiplier, e.g. the correlation downsize). A stop closer
+// than min_stop_percent or one ATR is sized at that distance, so a stop snapped
+// just under entry can't inflate the quantity. The quantity is then capped by
+// the available quote balance, max_notional_per_symbol and the symbol's
+// LOT_SIZE/NOTIONAL filters. entry, stopLoss, atr and available are in the
+// quote asset; notionalRate converts the quote asset to the notional asset.
+func (m *Manager) CalculateRiskSize(entry, stopLoss, atr, multiplier, available, notionalRate float64, info types.SymbolInfo) (RiskSize, error) {
+    cfg := m.config.Risk.PositionSizing
+    if notionalRate <= 0 {
+        notionalRate = 1
+    }
+    
+    size := RiskSize{Equity: m.sizingEquity()}
+    if size.Equity <= 0 {
+        return size, fmt.Errorf("no equity to size from")
+    }
+    if cfg.RiskPercent <= 0 {
+        return size, fmt.Errorf("risk_percent must be positive")
+    }
+    if stopLoss <= 0 || stopLoss >= entry {
+        return size, fmt.Errorf("stop %.8g is not below entry %.8g", stopLoss, entry)
+    }
+    
+    size.StopDistance = (entry - stopLoss) / entry * 100
+    size.RiskAmount = size.Equity * cfg.RiskPercent / 100 * multiplier
+    distance := entry - stopLoss
+    if minDistance := math.Max(entry*cfg.MinStopPercent/100, atr); distance < minDistance {
+        distance = minDistance
+        size.Caps = append(size.Caps, fmt.Sprintf("minimum stop distance %.2f%%", minDistance/entry*100))
+    }
+    quantity := size.RiskAmount / (distance * notionalRate)
+    quantity, err := m.fitQuantity(quantity, entry, available, notionalRate, info, &size.Caps)
+    if err != nil {
+        return size, err
+    }
+    
+    size.Quantity = quantity
+    size.Notional = quantity * entry * notionalRate
+    size.RiskAmount = quantity * (entry - stopLoss) * notionalRate
+    return size, nil
+}
+
+// sizingEquity is the last equity sample, or the starting balance before one
+func (m *Manager) sizingEquity() float64 {
+    if m.equity > 0 {
+        return m.equity
+    }
+    return m.initialBalance
+}
+
+// fitQuantity caps a quantity by the available quote balance,
+// max_notional_per_symbol and the exchange filters, rounds it down to the
+// step size and checks the exchange minimums. Caps that applied are appended.
+func (m *Manager) fitQuantity(quantity, entry, available, notionalRate float64, info types.SymbolInfo, caps *[]string) (float64, error) {
+    limit := func(max float64, reason string) {
+        if max >= 0 && quantity > max {
+            quantity = max
+            *caps = append(*caps, reason)
+        }
+    }
+    if available <= 0 {
+        return 0, fmt.Errorf("no free %s balance", info.QuoteAsset)
+    }
+    limit(available/entry, fmt.Sprintf("available balance %.2f %s", available, info.QuoteAsset))
+    if maxNotional := m.config.Risk.PositionSizing.MaxNotionalPerSymbol; maxNotional > 0 {
+        limit(maxNotional/(entry*notionalRate), fmt.Sprintf("max notional per symbol %.2f", maxNotional))
+    }
+    if info.MaxQty > 0 {
+        limit(info.MaxQty, fmt.Sprintf("exchange max quantity %.8g", info.MaxQty))
+    }
+    if info.MaxNotional > 0 {
+        limit(info.MaxNotional/entry, fmt.Sprintf("exchange max notional %.8g", info.MaxNotional))
+    }
+    
+    if info.StepSize > 0 {
+        quantity = math.Floor(quantity/info.StepSize+1e-9) * info.StepSize
+    }
+    if quantity <= 0 || quantity < info.MinQty || quantity*entry < info.MinNotional {
+        return 0, fmt.Errorf("quantity %.8g is below the exchange minimum (min qty %.8g, min notional %.8g %s)", 
+            quantity, info.MinQty, info.MinNotional, info.QuoteAsset)
+    }
+    return quantity, nil
+}
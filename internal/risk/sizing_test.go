@@ -0,0 +1,64 @@
+// File: internal/risk/sizing_test.go
+// ============================================
+package risk
+
+import (
+    "strings"
+    "testing"
+
+    "binance-trading-bot/pkg/types"
+)
+
+func TestCalculateRiskSizeMinimumStop(t *testing.T) {
+    info := types.SymbolInfo{QuoteAsset: "USDT", StepSize: 0.001}
+    tests := []struct {
+        name           string
+        stopLoss       float64
+        atr            float64
+        minStopPercent float64
+        wantQuantity   float64
+        wantCapped     bool
+    }{
+        {name: "stop beyond the minimum", stopLoss: 98, minStopPercent: 1, wantQuantity: 5},
+        {name: "snapped stop uses min_stop_percent", stopLoss: 99.9, minStopPercent: 1, wantQuantity: 10, wantCapped: true},
+        {name: "snapped stop uses ATR", stopLoss: 99.9, atr: 2.5, minStopPercent: 1, wantQuantity: 4, wantCapped: true},
+        {name: "no floor configured", stopLoss: 99.5, wantQuantity: 20},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            config := &types.Config{}
+            config.Risk.PositionSizing.RiskPercent = 1
+            config.Risk.PositionSizing.MinStopPercent = tt.minStopPercent
+            m := NewManager(config, 1000, nil)
+
+            size, err := m.CalculateRiskSize(100, tt.stopLoss, tt.atr, 1, 1e6, 1, info)
+            if err != nil {
+                t.Fatalf("CalculateRiskSize: %v", err)
+            }
+            if !sameQuantity(size.Quantity, tt.wantQuantity) {
+                t.Errorf("quantity = %.4f, want %.4f", size.Quantity, tt.wantQuantity)
+            }
+            capped := len(size.Caps) > 0 && strings.HasPrefix(size.Caps[0], "minimum stop distance")
+            if capped != tt.wantCapped {
+                t.Errorf("caps = %v, want minimum stop cap %v", size.Caps, tt.wantCapped)
+            }
+        })
+    }
+}
+
+func TestCalculateRiskSizeWithoutBalance(t *testing.T) {
+    config := &types.Config{}
+    config.Risk.PositionSizing.RiskPercent = 1
+    m := NewManager(config, 1000, nil)
+
+    _, err := m.CalculateRiskSize(100, 98, 0, 1, 0, 1, types.SymbolInfo{QuoteAsset: "USDT"})
+    if err == nil || !strings.Contains(err.Error(), "no free USDT balance") {
+        t.Errorf("err = %v, want a missing balance error", err)
+    }
+}
+
+func sameQuantity(a, b float64) bool {
+    diff := a - b
+    return diff < 1e-9 && diff > -1e-9
+}
@@ -0,0 +1,251 @@
+// File: internal/strategy/anomaly.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "log"
+    "math"
+    "sort"
+    "strings"
+)
+
+// anomalyATRPeriod is the ATR period candle spikes are measured in
+const anomalyATRPeriod = 14
+
+// anomalyKlines is how many klines the candle checks need: the lookback, a
+// full ATR period before it and the extra bar the true range reads
+func anomalyKlines(lookback int) int {
+    bars := lookback + anomalyATRPeriod + 2
+    if bars < 100 {
+        bars = 100 // Shared with the other 100-bar requests in the kline cache
+    }
+    return bars
+}
+
+// CandleSpikeATR returns the largest candle body among the last lookback
+// klines, measured in ATRs of the klines before them so the spike itself does
+// not inflate the yardstick
+func CandleSpikeATR(klines []types.Kline, lookback, atrPeriod int) float64 {
+    if len(klines) < lookback+2 {
+        return 0
+    }
+    base := klines[:len(klines)-lookback]
+    period := atrPeriod
+    if len(base)-1 < period {
+        period = len(base) - 1
+    }
+    atr := CalculateATR(base, period)
+    if atr <= 0 {
+        return 0
+    }
+
+    largest := 0.0
+    for _, k := range klines[len(klines)-lookback:] {
+        largest = math.Max(largest, math.Abs(k.Close-k.Open))
+    }
+    return largest / atr
+}
+
+// UpperWickRatio returns the summed upper wicks over the summed bodies of the
+// klines. Pumps that get sold into leave long upper wicks on small bodies.
+func UpperWickRatio(klines []types.Kline) float64 {
+    wicks, bodies := 0.0, 0.0
+    for _, k := range klines {
+        wicks += k.High - math.Max(k.Open, k.Close)
+        bodies += math.Abs(k.Close - k.Open)
+    }
+    if bodies == 0 {
+        return 0
+    }
+    return wicks / bodies
+}
+
+// TopTradeShare returns the share of traded quote volume in the n largest trades
+func TopTradeShare(trades []types.AggTrade, n int) float64 {
+    if len(trades) == 0 {
+        return 0
+    }
+    sizes := make([]float64, len(trades))
+    total := 0.0
+    for i, t := range trades {
+        sizes[i] = t.Price * t.Quantity
+        total += sizes[i]
+    }
+    if total == 0 {
+        return 0
+    }
+    sort.Sort(sort.Reverse(sort.Float64Slice(sizes)))
+    if n > len(sizes) {
+        n = len(sizes)
+    }
+    top := 0.0
+    for _, size := range sizes[:n] {
+        top += size
+    }
+    return top / total
+}
+
+// RepeatedSizeShare returns the share of trades whose quantity matches
+// another trade exactly - wash trading bots tend to reuse the same size
+func RepeatedSizeShare(trades []types.AggTrade) float64 {
+    if len(trades) == 0 {
+        return 0
+    }
+    counts := map[float64]int{}
+    for _, t := range trades {
+        counts[t.Quantity]++
+    }
+    repeated := 0
+    for _, count := range counts {
+        if count > 1 {
+            repeated += count
+        }
+    }
+    return float64(repeated) / float64(len(trades))
+}
+
+// SpreadPercent returns the best bid/ask spread relative to the mid price
+func SpreadPercent(book *types.OrderBook) float64 {
+    if book == nil || len(book.Bids) == 0 || len(book.Asks) == 0 {
+        return 0
+    }
+    bid, ask := book.Bids[0].Price, book.Asks[0].Price
+    mid := (bid + ask) / 2
+    if mid <= 0 {
+        return 0
+    }
+    return (ask - bid) / mid * 100
+}
+
+// BookDepth returns the quote value resting within percent of the mid price
+func BookDepth(book *types.OrderBook, percent float64) float64 {
+    if book == nil || len(book.Bids) == 0 || len(book.Asks) == 0 {
+        return 0
+    }
+    mid := (book.Bids[0].Price + book.Asks[0].Price) / 2
+    low, high := mid*(1-percent/100), mid*(1+percent/100)
+
+    depth := 0.0
+    for _, level := range book.Bids {
+        if level.Price < low {
+            break
+        }
+        depth += level.Price * level.Quantity
+    }
+    for _, level := range book.Asks {
+        if level.Price > high {
+            break
+        }
+        depth += level.Price * level.Quantity
+    }
+    return depth
+}
+
+// DetectAnomalies checks a symbol for pump-and-dump and wash-trading traits:
+// an extreme candle vs ATR, long upper wicks, volume concentrated in a few
+// trades, repeated trade sizes, a blown-out spread and a thin book. Checks
+// whose threshold is 0 are skipped, as are checks whose data failed to load.
+func (s *MomentumStrategy) DetectAnomalies(ticker types.Ticker) types.AnomalyReport {
+    cfg := s.config.Strategy.Anomaly
+    report := types.AnomalyReport{}
+
+    timeframe := cfg.Timeframe
+    if timeframe == "" {
+        timeframe = "1m"
+    }
+    lookback := cfg.LookbackBars
+    if lookback <= 0 {
+        lookback = 15
+    }
+    tradeCount := cfg.Trades
+    if tradeCount <= 0 {
+        tradeCount = 500
+    }
+    topTrades := cfg.TopTrades
+    if topTrades <= 0 {
+        topTrades = 5
+    }
+    minFlags := cfg.MinFlags
+    if minFlags <= 0 {
+        minFlags = 1
+    }
+
+    if cfg.MaxCandleATR > 0 || cfg.MaxUpperWickRatio > 0 {
+        klines, err := s.client.GetKlines(ticker.Symbol, timeframe, anomalyKlines(lookback))
+        if err != nil {
+            log.Printf("   ⚠️  Anomaly check: failed to get %s klines: %v", timeframe, err)
+        } else {
+            report.CandleATR = CandleSpikeATR(klines, lookback, anomalyATRPeriod)
+            if cfg.MaxCandleATR > 0 && report.CandleATR > cfg.MaxCandleATR {
+                report.Flags = append(report.Flags,
+                    fmt.Sprintf("%s candle of %.1f ATR", timeframe, report.CandleATR))
+            }
+
+            recent := klines
+            if len(recent) > lookback {
+                recent = recent[len(recent)-lookback:]
+            }
+            report.UpperWickRatio = UpperWickRatio(recent)
+            if cfg.MaxUpperWickRatio > 0 && report.UpperWickRatio > cfg.MaxUpperWickRatio {
+                report.Flags = append(report.Flags,
+                    fmt.Sprintf("upper wicks %.1fx bodies", report.UpperWickRatio))
+            }
+        }
+    }
+
+    if cfg.MaxTopTradeShare > 0 || cfg.MaxRepeatedSizeShare > 0 {
+        trades, err := s.client.GetAggTrades(ticker.Symbol, tradeCount)
+        if err != nil {
+            log.Printf("   ⚠️  Anomaly check: failed to get trades: %v", err)
+        } else {
+            report.TopTradeShare = TopTradeShare(trades, topTrades)
+            if cfg.MaxTopTradeShare > 0 && report.TopTradeShare > cfg.MaxTopTradeShare {
+                report.Flags = append(report.Flags,
+                    fmt.Sprintf("%.0f%% of volume in %d trades", report.TopTradeShare*100, topTrades))
+            }
+            report.RepeatedSizeShare = RepeatedSizeShare(trades)
+            if cfg.MaxRepeatedSizeShare > 0 && report.RepeatedSizeShare > cfg.MaxRepeatedSizeShare {
+                report.Flags = append(report.Flags,
+                    fmt.Sprintf("%.0f%% of trades repeat a size", report.RepeatedSizeShare*100))
+            }
+        }
+    }
+
+    if cfg.MaxSpreadPercent > 0 || cfg.MinDepthNotional > 0 {
+        book, err := s.client.GetOrderBook(ticker.Symbol, 100)
+        if err != nil {
+            log.Printf("   ⚠️  Anomaly check: failed to get order book: %v", err)
+        } else {
+            report.SpreadPercent = SpreadPercent(book)
+            if cfg.MaxSpreadPercent > 0 && report.SpreadPercent > cfg.MaxSpreadPercent {
+                report.Flags = append(report.Flags,
+                    fmt.Sprintf("spread %.2f%%", report.SpreadPercent))
+            }
+
+            rate := ticker.NotionalRate
+            if rate <= 0 {
+                rate = 1
+            }
+            report.DepthNotional = BookDepth(book, 2) * rate
+            if cfg.MinDepthNotional > 0 && report.DepthNotional < cfg.MinDepthNotional {
+                report.Flags = append(report.Flags,
+                    fmt.Sprintf("thin book ($%.0f within 2%%)", report.DepthNotional))
+            }
+        }
+    }
+
+    report.Flagged = len(report.Flags) >= minFlags
+    return report
+}
+
+// DescribeAnomaly formats an anomaly report for logs and notifications
+func DescribeAnomaly(r *types.AnomalyReport) string {
+    summary := fmt.Sprintf("candle %.1f ATR | wicks %.1fx | top trades %.0f%% | repeated sizes %.0f%% | spread %.2f%% | depth $%.0f",
+        r.CandleATR, r.UpperWickRatio, r.TopTradeShare*100, r.RepeatedSizeShare*100, r.SpreadPercent, r.DepthNotional)
+    if len(r.Flags) > 0 {
+        summary = strings.Join(r.Flags, ", ") + " | " + summary
+    }
+    return summary
+}
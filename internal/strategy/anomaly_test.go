@@ -0,0 +1,20 @@
+// File: internal/strategy/anomaly_test.go
+// ============================================
+package strategy
+
+import "testing"
+
+func TestAnomalyKlinesCoverLongLookbacks(t *testing.T) {
+    for _, lookback := range []int{15, 84, 99, 300} {
+        klines := wavyKlines(anomalyKlines(lookback))
+        // Turn the last bar into a spike so the check has something to find
+        last := &klines[len(klines)-1]
+        last.Close = last.Open * 1.5
+        last.High = last.Close
+
+        if got := CandleSpikeATR(klines, lookback, anomalyATRPeriod); got <= 0 {
+            t.Errorf("lookback %d: CandleSpikeATR = %.2f with %d klines, want a measured spike",
+                lookback, got, len(klines))
+        }
+    }
+}
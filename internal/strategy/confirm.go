@@ -0,0 +1,153 @@
+// File: internal/strategy/confirm.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "log"
+    "sort"
+    "time"
+)
+
+// PendingSignal is a BUY waiting for confirmation
+type PendingSignal struct {
+    Symbol      string
+    FirstPrice  float64 // Price at the first trigger
+    FirstSeen   time.Time
+    LastPrice   float64
+    Strength    float64
+    Passes      int     // Consecutive evaluations that produced a BUY
+    Confirmed   bool
+    Invalidated bool    // Price ran too far past the first trigger
+}
+
+// SignalConfirmer debounces BUY signals: a signal fires only after it passes
+// on N consecutive evaluations or holds for a minimum duration. A signal
+// whose price runs more than the allowed percent past the first trigger is
+// invalidated until it stops signalling.
+type SignalConfirmer struct {
+    minPasses   int
+    minDuration time.Duration
+    maxRun      float64
+    pending     map[string]*PendingSignal
+}
+
+// NewSignalConfirmer creates the confirmer from config
+func NewSignalConfirmer(config *types.Config) (*SignalConfirmer, error) {
+    cfg := config.Strategy.Confirmation
+    if cfg.MinEvaluations < 0 || cfg.MinDurationSeconds < 0 || cfg.MaxRunPercent < 0 {
+        return nil, fmt.Errorf("confirmation settings cannot be negative")
+    }
+    c := &SignalConfirmer{
+        minPasses:   cfg.MinEvaluations,
+        minDuration: time.Duration(cfg.MinDurationSeconds) * time.Second,
+        maxRun:      cfg.MaxRunPercent,
+        pending:     map[string]*PendingSignal{},
+    }
+    if c.minPasses == 0 && c.minDuration == 0 {
+        c.minPasses = 2
+    }
+    return c, nil
+}
+
+// Confirm records one cycle of evaluations and returns the signals with
+// unconfirmed BUYs downgraded to HOLD. Pending symbols that were not
+// evaluated or stopped signalling BUY start over.
+func (c *SignalConfirmer) Confirm(signals []types.Signal) []types.Signal {
+    now := time.Now()
+    evaluated := make(map[string]bool, len(signals))
+    confirmed := make([]types.Signal, 0, len(signals))
+
+    for _, signal := range signals {
+        evaluated[signal.Symbol] = true
+        if signal.Action != "BUY" {
+            delete(c.pending, signal.Symbol)
+            confirmed = append(confirmed, signal)
+            continue
+        }
+
+        p, ok := c.pending[signal.Symbol]
+        if !ok {
+            p = &PendingSignal{Symbol: signal.Symbol, FirstPrice: signal.Price, FirstSeen: now}
+            c.pending[signal.Symbol] = p
+        }
+        p.Passes++
+        p.LastPrice = signal.Price
+        p.Strength = signal.Strength
+
+        run := (signal.Price - p.FirstPrice) / p.FirstPrice * 100
+        if c.maxRun > 0 && run > c.maxRun && !p.Invalidated {
+            p.Invalidated = true
+            log.Printf("   ⏳ %s confirmation invalidated: price ran %+.2f%% past the first trigger", signal.Symbol, run)
+        }
+
+        held := now.Sub(p.FirstSeen)
+        p.Confirmed = !p.Invalidated &&
+            ((c.minPasses > 0 && p.Passes >= c.minPasses) || (c.minDuration > 0 && held >= c.minDuration))
+
+        if p.Confirmed {
+            if signal.Explanation != nil {
+                signal.Explanation.Notes = append(signal.Explanation.Notes,
+                    fmt.Sprintf("Confirmed after %d evaluations over %s", p.Passes, held.Round(time.Second)))
+            }
+            confirmed = append(confirmed, signal)
+            continue
+        }
+
+        if !p.Invalidated {
+            log.Printf("   ⏳ %s BUY pending confirmation (%s)", signal.Symbol, c.progress(p, now))
+        }
+        signal.Action = "HOLD"
+        signal.Reason = "Awaiting confirmation | " + signal.Reason
+        confirmed = append(confirmed, signal)
+    }
+
+    for symbol := range c.pending {
+        if !evaluated[symbol] {
+            delete(c.pending, symbol)
+        }
+    }
+    return confirmed
+}
+
+// Reset drops all pending signals, e.g. when a cycle skipped the evaluation
+func (c *SignalConfirmer) Reset() {
+    c.pending = map[string]*PendingSignal{}
+}
+
+// Pending returns the signals waiting for confirmation, oldest first
+func (c *SignalConfirmer) Pending() []PendingSignal {
+    pending := make([]PendingSignal, 0, len(c.pending))
+    for _, p := range c.pending {
+        pending = append(pending, *p)
+    }
+    sort.Slice(pending, func(i, j int) bool { return pending[i].FirstSeen.Before(pending[j].FirstSeen) })
+    return pending
+}
+
+// Describe formats a pending signal for the status report
+func (c *SignalConfirmer) Describe(p PendingSignal) string {
+    run := (p.LastPrice - p.FirstPrice) / p.FirstPrice * 100
+    state := c.progress(&p, time.Now())
+    switch {
+    case p.Invalidated:
+        state = "invalidated"
+    case p.Confirmed:
+        state = "confirmed"
+    }
+    return fmt.Sprintf("%s: %s | strength %.0f%% | %+.2f%% since first trigger at %.8g",
+        p.Symbol, state, p.Strength*100, run, p.FirstPrice)
+}
+
+// progress describes how far a pending signal is from confirmation
+func (c *SignalConfirmer) progress(p *PendingSignal, now time.Time) string {
+    held := now.Sub(p.FirstSeen).Round(time.Second)
+    switch {
+    case c.minPasses > 0 && c.minDuration > 0:
+        return fmt.Sprintf("%d/%d evaluations or %s/%s held", p.Passes, c.minPasses, held, c.minDuration)
+    case c.minPasses > 0:
+        return fmt.Sprintf("%d/%d evaluations", p.Passes, c.minPasses)
+    }
+    return fmt.Sprintf("%s/%s held", held, c.minDuration)
+}
@@ -0,0 +1,177 @@
+// File: internal/strategy/divergence.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "math"
+    "strings"
+)
+
+// DivergenceConfig controls swing detection for divergences
+type DivergenceConfig struct {
+    SwingBars          int  // Bars on each side that must be lower (highs) or higher (lows)
+    MaxAgeBars         int  // Most recent swing must be at most this many bars old
+    IncludeUnconfirmed bool // Treat the latest bar as a swing if it is the extreme of the last SwingBars
+}
+
+// DivergenceConfigFrom reads divergence settings from the strategy config
+func DivergenceConfigFrom(config *types.Config) DivergenceConfig {
+    cfg := DivergenceConfig{
+        SwingBars:          config.Strategy.Divergence.SwingBars,
+        MaxAgeBars:         config.Strategy.Divergence.MaxAgeBars,
+        IncludeUnconfirmed: config.Strategy.Divergence.IncludeUnconfirmed,
+    }
+    if cfg.SwingBars <= 0 {
+        cfg.SwingBars = 3
+    }
+    if cfg.MaxAgeBars <= 0 {
+        cfg.MaxAgeBars = 10
+    }
+    return cfg
+}
+
+// FindSwingHighs returns indexes whose value is strictly higher than the
+// bars on each side
+func FindSwingHighs(values []float64, bars int) []int {
+    return findSwings(values, bars, func(a, b float64) bool { return a > b })
+}
+
+// FindSwingLows returns indexes whose value is strictly lower than the bars on each side
+func FindSwingLows(values []float64, bars int) []int {
+    return findSwings(values, bars, func(a, b float64) bool { return a < b })
+}
+
+func findSwings(values []float64, bars int, beats func(a, b float64) bool) []int {
+    swings := []int{}
+    for i := bars; i < len(values)-bars; i++ {
+        if isSwing(values, i, bars, bars, beats) {
+            swings = append(swings, i)
+        }
+    }
+    return swings
+}
+
+func isSwing(values []float64, i, left, right int, beats func(a, b float64) bool) bool {
+    if math.IsNaN(values[i]) {
+        return false
+    }
+    for j := i - left; j <= i+right; j++ {
+        if j == i || j < 0 || j >= len(values) {
+            continue
+        }
+        if !beats(values[i], values[j]) {
+            return false
+        }
+    }
+    return true
+}
+
+// lastTwoSwings returns the last two swing indexes, optionally counting the
+// latest bar as a provisional swing
+func lastTwoSwings(values []float64, cfg DivergenceConfig, beats func(a, b float64) bool) (int, int, bool) {
+    swings := findSwings(values, cfg.SwingBars, beats)
+
+    last := len(values) - 1
+    if cfg.IncludeUnconfirmed && last >= cfg.SwingBars && isSwing(values, last, cfg.SwingBars, 0, beats) {
+        swings = append(swings, last)
+    }
+
+    if len(swings) < 2 {
+        return 0, 0, false
+    }
+    return swings[len(swings)-2], swings[len(swings)-1], true
+}
+
+// DetectDivergences compares price swings with an oscillator aligned to the
+// same klines. Regular divergences signal reversals, hidden ones continuation:
+//
+//   Regular bearish: price higher high, oscillator lower high
+//   Hidden bearish:  price lower high,  oscillator higher high
+//   Regular bullish: price lower low,   oscillator higher low
+//   Hidden bullish:  price higher low,  oscillator lower low
+func DetectDivergences(klines []types.Kline, oscillator []float64, indicator string, cfg DivergenceConfig) []types.Divergence {
+    if len(klines) != len(oscillator) || len(klines) < 2*cfg.SwingBars+2 {
+        return nil
+    }
+
+    highs := make([]float64, len(klines))
+    lows := make([]float64, len(klines))
+    for i, k := range klines {
+        highs[i] = k.High
+        lows[i] = k.Low
+    }
+
+    last := len(klines) - 1
+    divergences := []types.Divergence{}
+    add := func(kind, direction string, swing int) {
+        divergences = append(divergences, types.Divergence{
+            Indicator: indicator,
+            Kind:      kind,
+            Direction: direction,
+            BarsAgo:   last - swing,
+        })
+    }
+
+    higher := func(a, b float64) bool { return a > b }
+    lower := func(a, b float64) bool { return a < b }
+
+    if first, second, ok := lastTwoSwings(highs, cfg, higher); ok && last-second <= cfg.MaxAgeBars {
+        o1, o2 := oscillator[first], oscillator[second]
+        if !math.IsNaN(o1) && !math.IsNaN(o2) {
+            if highs[second] > highs[first] && o2 < o1 {
+                add("REGULAR", "BEARISH", second)
+            } else if highs[second] < highs[first] && o2 > o1 {
+                add("HIDDEN", "BEARISH", second)
+            }
+        }
+    }
+
+    if first, second, ok := lastTwoSwings(lows, cfg, lower); ok && last-second <= cfg.MaxAgeBars {
+        o1, o2 := oscillator[first], oscillator[second]
+        if !math.IsNaN(o1) && !math.IsNaN(o2) {
+            if lows[second] < lows[first] && o2 > o1 {
+                add("REGULAR", "BULLISH", second)
+            } else if lows[second] > lows[first] && o2 < o1 {
+                add("HIDDEN", "BULLISH", second)
+            }
+        }
+    }
+
+    return divergences
+}
+
+// DetectAllDivergences checks every configured oscillator on one timeframe
+func DetectAllDivergences(klines []types.Kline, timeframe string, indicators []string, cfg DivergenceConfig) []types.Divergence {
+    if len(indicators) == 0 {
+        indicators = []string{"RSI", "MACD", "OBV"}
+    }
+
+    closes := ClosePrices(klines)
+    all := []types.Divergence{}
+    for _, indicator := range indicators {
+        var oscillator []float64
+        switch strings.ToUpper(indicator) {
+        case "RSI":
+            oscillator = RSISeries(closes, 14)
+        case "MACD":
+            _, _, oscillator = MACDSeries(closes)
+        case "OBV":
+            oscillator = OBVSeries(klines)
+        default:
+            continue
+        }
+        for _, d := range DetectDivergences(klines, oscillator, strings.ToUpper(indicator), cfg) {
+            d.Timeframe = timeframe
+            all = append(all, d)
+        }
+    }
+    return all
+}
+
+// DescribeDivergence formats a divergence for logs and reasons
+func DescribeDivergence(d types.Divergence) string {
+    return fmt.Sprintf("%s %s %s divergence (%s, %d bars ago)",
+        strings.ToLower(d.Kind), strings.ToLower(d.Direction), d.Indicator, d.Timeframe, d.BarsAgo)
+}
@@ -0,0 +1,139 @@
+// File: internal/strategy/explain.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "strings"
+)
+
+// NewSignalExplanation converts a rule evaluation and its context into the
+// structured breakdown attached to signals
+func NewSignalExplanation(eval RuleEvaluation, ctx *SignalContext, threshold float64) *types.SignalExplanation {
+    explanation := &types.SignalExplanation{
+        Score:            eval.Score,
+        MaxScore:         eval.MaxScore,
+        Strength:         eval.Strength,
+        Threshold:        threshold,
+        Regime:           ctx.Regime,
+        RegimeConfidence: ctx.RegimeConfidence,
+        Rejected:         eval.Rejected,
+        RejectReason:     eval.RejectReason,
+        Timeframes:       ctx.MTFAnalyses,
+        Indicators: map[string]float64{
+            "rsi":       ctx.RSI,
+            "bb_lower":  ctx.LowerBB,
+            "bb_upper":  ctx.UpperBB,
+            "atr":       ctx.ATR,
+            "mtf_score": ctx.MTFScore,
+        },
+    }
+
+    for _, o := range eval.Outcomes {
+        explanation.Criteria = append(explanation.Criteria, types.SignalCriterion{
+            Name:      o.Rule.Name,
+            Criterion: o.Rule.Criterion,
+            Type:      o.Rule.Type,
+            Value:     o.Result.Value,
+            Threshold: o.Result.Threshold,
+            Passed:    o.Result.Passed,
+            Weight:    o.Rule.Weight,
+            Points:    o.Points,
+            Detail:    o.Result.Detail,
+        })
+    }
+
+    for _, p := range ctx.Patterns {
+        explanation.Patterns = append(explanation.Patterns, p.String())
+    }
+
+    return explanation
+}
+
+// PassedCriteria returns the details of scored criteria that contributed points
+func PassedCriteria(e *types.SignalExplanation) []string {
+    details := []string{}
+    for _, c := range e.Criteria {
+        if c.Type == RuleScored && c.Passed && c.Points > 0 {
+            details = append(details, c.Detail)
+        }
+    }
+    return details
+}
+
+// FailedCriteria returns the details of positively weighted scored criteria that did not pass
+func FailedCriteria(e *types.SignalExplanation) []string {
+    details := []string{}
+    for _, c := range e.Criteria {
+        if c.Type == RuleScored && !c.Passed && c.Weight > 0 {
+            details = append(details, c.Detail)
+        }
+    }
+    return details
+}
+
+// TimeframeSummary formats the per-timeframe trends, e.g. "5m:BULLISH, 1h:NEUTRAL"
+func TimeframeSummary(analyses []types.TimeframeAnalysis) string {
+    if len(analyses) == 0 {
+        return "No MTF"
+    }
+    parts := make([]string, len(analyses))
+    for i, a := range analyses {
+        parts[i] = fmt.Sprintf("%s:%s", a.Timeframe, a.Trend)
+    }
+    return strings.Join(parts, ", ")
+}
+
+// ExplanationReason renders the short free-text reason for a signal
+func ExplanationReason(action string, e *types.SignalExplanation) string {
+    strength := e.Strength
+
+    var reason string
+    switch {
+    case e.Rejected:
+        reason = fmt.Sprintf("Rejected: %s", e.RejectReason)
+    case action == "BUY":
+        reason = fmt.Sprintf("Score: %.0f%% | %s", strength*100, strings.Join(PassedCriteria(e), ", "))
+        reason += fmt.Sprintf("\n   RSI: %.1f | BB: $%.4f-$%.4f", e.Indicators["rsi"], e.Indicators["bb_lower"], e.Indicators["bb_upper"])
+        reason += fmt.Sprintf("\n   MTF: %.0f%% (%s)", e.Indicators["mtf_score"]*100, TimeframeSummary(e.Timeframes))
+        if len(e.Patterns) > 0 {
+            reason += fmt.Sprintf("\n   Patterns: %s", strings.Join(e.Patterns, ", "))
+        }
+        return reason
+    default:
+        reason = fmt.Sprintf("Score too low (%.0f%% < %.0f%%): %s",
+            strength*100, e.Threshold*100, strings.Join(FailedCriteria(e), ", "))
+    }
+
+    if len(e.Patterns) > 0 {
+        reason += fmt.Sprintf(" | Patterns: %s", strings.Join(e.Patterns, ", "))
+    }
+    return reason
+}
+
+// ExplanationLines renders the full breakdown for the log, one line per item
+func ExplanationLines(e *types.SignalExplanation) []string {
+    lines := []string{}
+    for _, c := range e.Criteria {
+        mark := "❌"
+        if c.Passed {
+            mark = "✅"
+        }
+        line := fmt.Sprintf("%s %s [%s]: %s", mark, c.Name, c.Type, c.Detail)
+        if c.Type == RuleScored {
+            line += fmt.Sprintf(" (%+.0f/%.0f pts)", c.Points, c.Weight)
+        }
+        lines = append(lines, line)
+    }
+
+    lines = append(lines, fmt.Sprintf("📊 SCORE: %.0f/%.0f | Strength: %.1f%% | Threshold: %.0f%% | Regime: %s (%.0f%%)",
+        e.Score, e.MaxScore, e.Strength*100, e.Threshold*100, e.Regime, e.RegimeConfidence*100))
+    if e.ModelProbability != nil {
+        lines = append(lines, fmt.Sprintf("🤖 Model probability: %.1f%%", *e.ModelProbability*100))
+    }
+    for _, note := range e.Notes {
+        lines = append(lines, "⚙️  "+note)
+    }
+    return lines
+}
@@ -0,0 +1,720 @@
+// File: internal/strategy/expr.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "math"
+    "sort"
+    "strconv"
+    "strings"
+    "unicode"
+)
+
+// Expression language for custom signal conditions, e.g.
+//
+//   rsi(14, "15m") < 35 && close > ema(200, "1h") && volume_ratio > 2
+//
+// Expressions are compiled once at config load. Indicator functions take their
+// numeric parameters followed by an optional timeframe (default "1m").
+
+// DefaultExprTimeframe is used when an indicator call has no timeframe argument
+const DefaultExprTimeframe = "1m"
+
+// validTimeframes are the kline intervals supported by Binance
+var validTimeframes = map[string]bool{
+    "1m": true, "3m": true, "5m": true, "15m": true, "30m": true,
+    "1h": true, "2h": true, "4h": true, "6h": true, "8h": true, "12h": true,
+    "1d": true, "3d": true, "1w": true, "1M": true,
+}
+
+// ExprValue is a number or a string; booleans are 1 and 0
+type ExprValue struct {
+    Num   float64
+    Str   string
+    IsStr bool
+}
+
+func numValue(f float64) ExprValue { return ExprValue{Num: f} }
+
+func boolValue(b bool) ExprValue {
+    if b {
+        return ExprValue{Num: 1}
+    }
+    return ExprValue{Num: 0}
+}
+
+// Truthy reports whether the value counts as true
+func (v ExprValue) Truthy() bool {
+    if v.IsStr {
+        return v.Str != ""
+    }
+    return v.Num != 0 && !math.IsNaN(v.Num)
+}
+
+func (v ExprValue) String() string {
+    if v.IsStr {
+        return strconv.Quote(v.Str)
+    }
+    return strconv.FormatFloat(v.Num, 'f', -1, 64)
+}
+
+// ExprEnv supplies variables and kline data when an expression is evaluated
+type ExprEnv interface {
+    Var(name string) (ExprValue, bool)
+    Klines(timeframe string) []types.Kline
+}
+
+// Expr is a compiled expression
+type Expr struct {
+    Source     string
+    root       exprNode
+    variables  map[string]bool
+    timeframes map[string]int // timeframe -> bars needed
+}
+
+// Uses reports whether the expression references the variable
+func (e *Expr) Uses(name string) bool {
+    return e.variables[name]
+}
+
+// Timeframes returns the kline timeframes referenced by the expression and
+// how many bars each needs
+func (e *Expr) Timeframes() map[string]int {
+    return e.timeframes
+}
+
+// Eval evaluates the expression against an environment
+func (e *Expr) Eval(env ExprEnv) (ExprValue, error) {
+    v, err := e.root.eval(env)
+    if err != nil {
+        return v, fmt.Errorf("%s: %v", e.Source, err)
+    }
+    return v, nil
+}
+
+// EvalBool evaluates the expression as a condition
+func (e *Expr) EvalBool(env ExprEnv) (bool, error) {
+    v, err := e.Eval(env)
+    if err != nil {
+        return false, err
+    }
+    return v.Truthy(), nil
+}
+
+// CompileExpr parses an expression, checking function names, argument counts,
+// timeframes and variables against the allowed set
+func CompileExpr(source string, vars map[string]bool) (*Expr, error) {
+    tokens, err := lexExpr(source)
+    if err != nil {
+        return nil, fmt.Errorf("expression %q: %v", source, err)
+    }
+
+    p := &exprParser{tokens: tokens, vars: vars, used: make(map[string]bool), timeframes: make(map[string]int)}
+    root, err := p.parseOr()
+    if err == nil && p.peek().kind != tokEOF {
+        err = p.errorf(p.peek(), "unexpected %s", p.peek())
+    }
+    if err != nil {
+        return nil, fmt.Errorf("expression %q: %v", source, err)
+    }
+
+    return &Expr{Source: source, root: root, variables: p.used, timeframes: p.timeframes}, nil
+}
+
+// ============================================
+// Functions
+// ============================================
+
+type exprFunc struct {
+    args      int  // Number of numeric arguments
+    indicator bool // Accepts an optional trailing timeframe and reads klines
+    warmup    func(args []float64) int
+    fn        func(klines []types.Kline, args []float64) float64
+}
+
+func periodWarmup(multiplier int) func(args []float64) int {
+    return func(args []float64) int {
+        return int(args[0])*multiplier + 1
+    }
+}
+
+func fixedWarmup(bars int) func(args []float64) int {
+    return func(args []float64) int { return bars }
+}
+
+// exprFuncs is the table of functions available to expressions
+var exprFuncs = map[string]exprFunc{
+    "rsi": {args: 1, indicator: true, warmup: periodWarmup(3),
+        fn: func(k []types.Kline, a []float64) float64 { return CalculateRSI(ClosePrices(k), int(a[0])) }},
+    "sma": {args: 1, indicator: true, warmup: periodWarmup(1),
+        fn: func(k []types.Kline, a []float64) float64 { return CalculateSMA(ClosePrices(k), int(a[0])) }},
+    "ema": {args: 1, indicator: true, warmup: periodWarmup(2),
+        fn: func(k []types.Kline, a []float64) float64 { return CalculateEMA(ClosePrices(k), int(a[0])) }},
+    "macd": {args: 0, indicator: true, warmup: fixedWarmup(100),
+        fn: func(k []types.Kline, a []float64) float64 { m, _, _ := CalculateMACD(ClosePrices(k)); return m }},
+    "macd_signal": {args: 0, indicator: true, warmup: fixedWarmup(100),
+        fn: func(k []types.Kline, a []float64) float64 { _, s, _ := CalculateMACD(ClosePrices(k)); return s }},
+    "macd_hist": {args: 0, indicator: true, warmup: fixedWarmup(100),
+        fn: func(k []types.Kline, a []float64) float64 { _, _, h := CalculateMACD(ClosePrices(k)); return h }},
+    "bb_upper": {args: 2, indicator: true, warmup: periodWarmup(1),
+        fn: func(k []types.Kline, a []float64) float64 { u, _, _ := CalculateBollingerBands(ClosePrices(k), int(a[0]), a[1]); return u }},
+    "bb_middle": {args: 2, indicator: true, warmup: periodWarmup(1),
+        fn: func(k []types.Kline, a []float64) float64 { _, m, _ := CalculateBollingerBands(ClosePrices(k), int(a[0]), a[1]); return m }},
+    "bb_lower": {args: 2, indicator: true, warmup: periodWarmup(1),
+        fn: func(k []types.Kline, a []float64) float64 { _, _, l := CalculateBollingerBands(ClosePrices(k), int(a[0]), a[1]); return l }},
+    "atr": {args: 1, indicator: true, warmup: periodWarmup(1),
+        fn: func(k []types.Kline, a []float64) float64 { return CalculateATR(k, int(a[0])) }},
+    "stoch_k": {args: 1, indicator: true, warmup: periodWarmup(1),
+        fn: func(k []types.Kline, a []float64) float64 { v, _ := CalculateStochastic(k, int(a[0])); return v }},
+    "vwap": {args: 0, indicator: true, warmup: fixedWarmup(100),
+        fn: func(k []types.Kline, a []float64) float64 { return CalculateVWAP(k) }},
+    "close": {args: 0, indicator: true, warmup: fixedWarmup(1),
+        fn: func(k []types.Kline, a []float64) float64 { return k[len(k)-1].Close }},
+    "change": {args: 1, indicator: true, warmup: periodWarmup(1),
+        fn: func(k []types.Kline, a []float64) float64 {
+            n := int(a[0])
+            if len(k) <= n {
+                return 0
+            }
+            prev := k[len(k)-1-n].Close
+            if prev == 0 {
+                return 0
+            }
+            return (k[len(k)-1].Close - prev) / prev * 100
+        }},
+    "adx": {args: 1, indicator: true, warmup: periodWarmup(3),
+        fn: func(k []types.Kline, a []float64) float64 { v, _, _ := CalculateADX(k, int(a[0])); return v }},
+    "plus_di": {args: 1, indicator: true, warmup: periodWarmup(3),
+        fn: func(k []types.Kline, a []float64) float64 { _, v, _ := CalculateADX(k, int(a[0])); return v }},
+    "minus_di": {args: 1, indicator: true, warmup: periodWarmup(3),
+        fn: func(k []types.Kline, a []float64) float64 { _, _, v := CalculateADX(k, int(a[0])); return v }},
+    "supertrend": {args: 2, indicator: true, warmup: periodWarmup(3),
+        fn: func(k []types.Kline, a []float64) float64 { v, _ := CalculateSuperTrend(k, int(a[0]), a[1]); return v }},
+    "mfi": {args: 1, indicator: true, warmup: periodWarmup(1),
+        fn: func(k []types.Kline, a []float64) float64 { return CalculateMFI(k, int(a[0])) }},
+    "cci": {args: 1, indicator: true, warmup: periodWarmup(1),
+        fn: func(k []types.Kline, a []float64) float64 { return CalculateCCI(k, int(a[0])) }},
+    "williams_r": {args: 1, indicator: true, warmup: periodWarmup(1),
+        fn: func(k []types.Kline, a []float64) float64 { return CalculateWilliamsR(k, int(a[0])) }},
+    "obv": {args: 0, indicator: true, warmup: fixedWarmup(100),
+        fn: func(k []types.Kline, a []float64) float64 { return CalculateOBV(k) }},
+    "psar": {args: 0, indicator: true, warmup: fixedWarmup(100),
+        fn: func(k []types.Kline, a []float64) float64 { v, _ := CalculateParabolicSAR(k, 0.02, 0.2); return v }},
+    "keltner_upper": {args: 2, indicator: true, warmup: periodWarmup(2),
+        fn: func(k []types.Kline, a []float64) float64 { u, _, _ := CalculateKeltnerChannels(k, int(a[0]), a[1]); return u }},
+    "keltner_lower": {args: 2, indicator: true, warmup: periodWarmup(2),
+        fn: func(k []types.Kline, a []float64) float64 { _, _, l := CalculateKeltnerChannels(k, int(a[0]), a[1]); return l }},
+    "abs": {args: 1, fn: func(k []types.Kline, a []float64) float64 { return math.Abs(a[0]) }},
+    "min": {args: 2, fn: func(k []types.Kline, a []float64) float64 { return math.Min(a[0], a[1]) }},
+    "max": {args: 2, fn: func(k []types.Kline, a []float64) float64 { return math.Max(a[0], a[1]) }},
+}
+
+// ============================================
+// Lexer
+// ============================================
+
+type tokenKind int
+
+const (
+    tokEOF tokenKind = iota
+    tokNumber
+    tokString
+    tokIdent
+    tokOp
+    tokLParen
+    tokRParen
+    tokComma
+)
+
+type token struct {
+    kind tokenKind
+    text string
+    pos  int
+}
+
+func (t token) String() string {
+    if t.kind == tokEOF {
+        return "end of expression"
+    }
+    return strconv.Quote(t.text)
+}
+
+func lexExpr(src string) ([]token, error) {
+    tokens := []token{}
+    i := 0
+    for i < len(src) {
+        c := rune(src[i])
+        switch {
+        case unicode.IsSpace(c):
+            i++
+        case unicode.IsDigit(c) || (c == '.' && i+1 < len(src) && unicode.IsDigit(rune(src[i+1]))):
+            start := i
+            for i < len(src) && (unicode.IsDigit(rune(src[i])) || src[i] == '.') {
+                i++
+            }
+            tokens = append(tokens, token{tokNumber, src[start:i], start})
+        case c == '"' || c == '\'':
+            start := i
+            i++
+            for i < len(src) && rune(src[i]) != c {
+                i++
+            }
+            if i >= len(src) {
+                return nil, fmt.Errorf("position %d: unterminated string", start+1)
+            }
+            tokens = append(tokens, token{tokString, src[start+1 : i], start})
+            i++
+        case unicode.IsLetter(c) || c == '_':
+            start := i
+            for i < len(src) && (unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i])) || src[i] == '_') {
+                i++
+            }
+            tokens = append(tokens, token{tokIdent, src[start:i], start})
+        case c == '(':
+            tokens = append(tokens, token{tokLParen, "(", i})
+            i++
+        case c == ')':
+            tokens = append(tokens, token{tokRParen, ")", i})
+            i++
+        case c == ',':
+            tokens = append(tokens, token{tokComma, ",", i})
+            i++
+        default:
+            if i+1 < len(src) {
+                two := src[i : i+2]
+                switch two {
+                case "&&", "||", "<=", ">=", "==", "!=":
+                    tokens = append(tokens, token{tokOp, two, i})
+                    i += 2
+                    continue
+                }
+            }
+            if strings.ContainsRune("+-*/<>!", c) {
+                tokens = append(tokens, token{tokOp, string(c), i})
+                i++
+                continue
+            }
+            return nil, fmt.Errorf("position %d: unexpected character %q", i+1, c)
+        }
+    }
+    return append(tokens, token{tokEOF, "", len(src)}), nil
+}
+
+// ============================================
+// Parser
+// ============================================
+
+type exprParser struct {
+    tokens     []token
+    pos        int
+    vars       map[string]bool
+    used       map[string]bool
+    timeframes map[string]int
+}
+
+func (p *exprParser) peek() token { return p.tokens[p.pos] }
+
+func (p *exprParser) next() token {
+    t := p.tokens[p.pos]
+    if t.kind != tokEOF {
+        p.pos++
+    }
+    return t
+}
+
+func (p *exprParser) errorf(t token, format string, args ...interface{}) error {
+    return fmt.Errorf("position %d: %s", t.pos+1, fmt.Sprintf(format, args...))
+}
+
+func (p *exprParser) acceptOp(ops ...string) (string, bool) {
+    t := p.peek()
+    if t.kind != tokOp {
+        return "", false
+    }
+    for _, op := range ops {
+        if t.text == op {
+            p.next()
+            return op, true
+        }
+    }
+    return "", false
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+    left, err := p.parseAnd()
+    if err != nil {
+        return nil, err
+    }
+    for {
+        if _, ok := p.acceptOp("||"); !ok {
+            return left, nil
+        }
+        right, err := p.parseAnd()
+        if err != nil {
+            return nil, err
+        }
+        left = &binaryNode{op: "||", left: left, right: right}
+    }
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+    left, err := p.parseNot()
+    if err != nil {
+        return nil, err
+    }
+    for {
+        if _, ok := p.acceptOp("&&"); !ok {
+            return left, nil
+        }
+        right, err := p.parseNot()
+        if err != nil {
+            return nil, err
+        }
+        left = &binaryNode{op: "&&", left: left, right: right}
+    }
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+    if _, ok := p.acceptOp("!"); ok {
+        operand, err := p.parseNot()
+        if err != nil {
+            return nil, err
+        }
+        return &unaryNode{op: "!", operand: operand}, nil
+    }
+    return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+    left, err := p.parseAdditive()
+    if err != nil {
+        return nil, err
+    }
+    if op, ok := p.acceptOp("<", "<=", ">", ">=", "==", "!="); ok {
+        right, err := p.parseAdditive()
+        if err != nil {
+            return nil, err
+        }
+        return &binaryNode{op: op, left: left, right: right}, nil
+    }
+    return left, nil
+}
+
+func (p *exprParser) parseAdditive() (exprNode, error) {
+    left, err := p.parseMultiplicative()
+    if err != nil {
+        return nil, err
+    }
+    for {
+        op, ok := p.acceptOp("+", "-")
+        if !ok {
+            return left, nil
+        }
+        right, err := p.parseMultiplicative()
+        if err != nil {
+            return nil, err
+        }
+        left = &binaryNode{op: op, left: left, right: right}
+    }
+}
+
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+    left, err := p.parseUnary()
+    if err != nil {
+        return nil, err
+    }
+    for {
+        op, ok := p.acceptOp("*", "/")
+        if !ok {
+            return left, nil
+        }
+        right, err := p.parseUnary()
+        if err != nil {
+            return nil, err
+        }
+        left = &binaryNode{op: op, left: left, right: right}
+    }
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+    if _, ok := p.acceptOp("-"); ok {
+        operand, err := p.parseUnary()
+        if err != nil {
+            return nil, err
+        }
+        return &unaryNode{op: "-", operand: operand}, nil
+    }
+    return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+    t := p.next()
+    switch t.kind {
+    case tokNumber:
+        f, err := strconv.ParseFloat(t.text, 64)
+        if err != nil {
+            return nil, p.errorf(t, "invalid number %q", t.text)
+        }
+        return &literalNode{value: numValue(f)}, nil
+    case tokString:
+        return &literalNode{value: ExprValue{Str: t.text, IsStr: true}}, nil
+    case tokLParen:
+        inner, err := p.parseOr()
+        if err != nil {
+            return nil, err
+        }
+        if closing := p.next(); closing.kind != tokRParen {
+            return nil, p.errorf(closing, "expected \")\" but found %s", closing)
+        }
+        return inner, nil
+    case tokIdent:
+        if p.peek().kind == tokLParen {
+            return p.parseCall(t)
+        }
+        if !p.vars[t.text] {
+            return nil, p.errorf(t, "unknown variable %q (available: %s)", t.text, strings.Join(sortedKeys(p.vars), ", "))
+        }
+        p.used[t.text] = true
+        return &varNode{name: t.text}, nil
+    }
+    return nil, p.errorf(t, "unexpected %s", t)
+}
+
+func (p *exprParser) parseCall(name token) (exprNode, error) {
+    fn, ok := exprFuncs[name.text]
+    if !ok {
+        names := make([]string, 0, len(exprFuncs))
+        for n := range exprFuncs {
+            names = append(names, n)
+        }
+        sort.Strings(names)
+        return nil, p.errorf(name, "unknown function %q (available: %s)", name.text, strings.Join(names, ", "))
+    }
+    p.next() // (
+
+    args := []exprNode{}
+    if p.peek().kind != tokRParen {
+        for {
+            arg, err := p.parseOr()
+            if err != nil {
+                return nil, err
+            }
+            args = append(args, arg)
+            if p.peek().kind != tokComma {
+                break
+            }
+            p.next()
+        }
+    }
+    if closing := p.next(); closing.kind != tokRParen {
+        return nil, p.errorf(closing, "expected \")\" but found %s", closing)
+    }
+
+    timeframe := DefaultExprTimeframe
+    if fn.indicator && len(args) == fn.args+1 {
+        lit, ok := args[fn.args].(*literalNode)
+        if !ok || !lit.value.IsStr {
+            return nil, p.errorf(name, "%s: timeframe must be a string literal like \"15m\"", name.text)
+        }
+        if !validTimeframes[lit.value.Str] {
+            return nil, p.errorf(name, "%s: unknown timeframe %q", name.text, lit.value.Str)
+        }
+        timeframe = lit.value.Str
+        args = args[:fn.args]
+    }
+    if len(args) != fn.args {
+        if fn.indicator {
+            return nil, p.errorf(name, "%s expects %d argument(s) plus an optional timeframe, got %d", name.text, fn.args, len(args))
+        }
+        return nil, p.errorf(name, "%s expects %d argument(s), got %d", name.text, fn.args, len(args))
+    }
+
+    node := &callNode{name: name.text, fn: fn, args: args, timeframe: timeframe}
+
+    if fn.indicator {
+        // Indicator parameters must be constants so the warm-up can be sized up front
+        constArgs := make([]float64, len(args))
+        for i, arg := range args {
+            lit, ok := arg.(*literalNode)
+            if !ok || lit.value.IsStr {
+                return nil, p.errorf(name, "%s: argument %d must be a number", name.text, i+1)
+            }
+            if lit.value.Num <= 0 {
+                return nil, p.errorf(name, "%s: argument %d must be positive", name.text, i+1)
+            }
+            constArgs[i] = lit.value.Num
+        }
+        bars := fn.warmup(constArgs)
+        if bars > 1000 {
+            return nil, p.errorf(name, "%s needs %d bars, more than the 1000 Binance returns", name.text, bars)
+        }
+        if bars > p.timeframes[timeframe] {
+            p.timeframes[timeframe] = bars
+        }
+    }
+
+    return node, nil
+}
+
+func sortedKeys(m map[string]bool) []string {
+    keys := make([]string, 0, len(m))
+    for k := range m {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+    return keys
+}
+
+// ============================================
+// AST
+// ============================================
+
+type exprNode interface {
+    eval(env ExprEnv) (ExprValue, error)
+}
+
+type literalNode struct{ value ExprValue }
+
+func (n *literalNode) eval(env ExprEnv) (ExprValue, error) { return n.value, nil }
+
+type varNode struct{ name string }
+
+func (n *varNode) eval(env ExprEnv) (ExprValue, error) {
+    v, ok := env.Var(n.name)
+    if !ok {
+        return v, fmt.Errorf("variable %q not available", n.name)
+    }
+    return v, nil
+}
+
+type unaryNode struct {
+    op      string
+    operand exprNode
+}
+
+func (n *unaryNode) eval(env ExprEnv) (ExprValue, error) {
+    v, err := n.operand.eval(env)
+    if err != nil {
+        return v, err
+    }
+    if n.op == "!" {
+        return boolValue(!v.Truthy()), nil
+    }
+    if v.IsStr {
+        return v, fmt.Errorf("cannot negate string %s", v)
+    }
+    return numValue(-v.Num), nil
+}
+
+type binaryNode struct {
+    op          string
+    left, right exprNode
+}
+
+func (n *binaryNode) eval(env ExprEnv) (ExprValue, error) {
+    left, err := n.left.eval(env)
+    if err != nil {
+        return left, err
+    }
+
+    // Short-circuit logical operators
+    switch n.op {
+    case "&&":
+        if !left.Truthy() {
+            return boolValue(false), nil
+        }
+        right, err := n.right.eval(env)
+        if err != nil {
+            return right, err
+        }
+        return boolValue(right.Truthy()), nil
+    case "||":
+        if left.Truthy() {
+            return boolValue(true), nil
+        }
+        right, err := n.right.eval(env)
+        if err != nil {
+            return right, err
+        }
+        return boolValue(right.Truthy()), nil
+    }
+
+    right, err := n.right.eval(env)
+    if err != nil {
+        return right, err
+    }
+
+    if left.IsStr || right.IsStr {
+        if !left.IsStr || !right.IsStr {
+            return ExprValue{}, fmt.Errorf("cannot compare %s with %s", left, right)
+        }
+        switch n.op {
+        case "==":
+            return boolValue(left.Str == right.Str), nil
+        case "!=":
+            return boolValue(left.Str != right.Str), nil
+        }
+        return ExprValue{}, fmt.Errorf("operator %s not supported on strings", n.op)
+    }
+
+    a, b := left.Num, right.Num
+    switch n.op {
+    case "+":
+        return numValue(a + b), nil
+    case "-":
+        return numValue(a - b), nil
+    case "*":
+        return numValue(a * b), nil
+    case "/":
+        if b == 0 {
+            return numValue(math.NaN()), nil
+        }
+        return numValue(a / b), nil
+    case "<":
+        return boolValue(a < b), nil
+    case "<=":
+        return boolValue(a <= b), nil
+    case ">":
+        return boolValue(a > b), nil
+    case ">=":
+        return boolValue(a >= b), nil
+    case "==":
+        return boolValue(a == b), nil
+    case "!=":
+        return boolValue(a != b), nil
+    }
+    return ExprValue{}, fmt.Errorf("unknown operator %s", n.op)
+}
+
+type callNode struct {
+    name      string
+    fn        exprFunc
+    args      []exprNode
+    timeframe string
+}
+
+func (n *callNode) eval(env ExprEnv) (ExprValue, error) {
+    args := make([]float64, len(n.args))
+    for i, arg := range n.args {
+        v, err := arg.eval(env)
+        if err != nil {
+            return v, err
+        }
+        if v.IsStr {
+            return v, fmt.Errorf("%s: argument %d must be a number", n.name, i+1)
+        }
+        args[i] = v.Num
+    }
+
+    var klines []types.Kline
+    if n.fn.indicator {
+        klines = env.Klines(n.timeframe)
+        if len(klines) == 0 {
+            return ExprValue{}, fmt.Errorf("%s: no %s klines available", n.name, n.timeframe)
+        }
+    }
+
+    return numValue(n.fn.fn(klines, args)), nil
+}
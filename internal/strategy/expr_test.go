@@ -0,0 +1,254 @@
+// File: internal/strategy/expr_test.go
+// ============================================
+package strategy
+
+import (
+    "math"
+    "strings"
+    "testing"
+
+    "binance-trading-bot/pkg/types"
+)
+
+// testEnv is an ExprEnv over fixed variables and klines
+type testEnv struct {
+    vars   map[string]ExprValue
+    klines map[string][]types.Kline
+}
+
+func (e testEnv) Var(name string) (ExprValue, bool) {
+    v, ok := e.vars[name]
+    return v, ok
+}
+
+func (e testEnv) Klines(timeframe string) []types.Kline {
+    return e.klines[timeframe]
+}
+
+var exprTestVars = map[string]bool{"x": true, "y": true, "nan": true, "regime": true, "missing": true}
+
+func exprTestEnv() testEnv {
+    return testEnv{
+        vars: map[string]ExprValue{
+            "x":      numValue(4),
+            "y":      numValue(2),
+            "nan":    numValue(math.NaN()),
+            "regime": {Str: "TRENDING", IsStr: true},
+        },
+        klines: map[string][]types.Kline{"1m": wavyKlines(60)},
+    }
+}
+
+func evalExpr(t *testing.T, source string) (ExprValue, error) {
+    t.Helper()
+    expr, err := CompileExpr(source, exprTestVars)
+    if err != nil {
+        t.Fatalf("CompileExpr(%q): %v", source, err)
+    }
+    return expr.Eval(exprTestEnv())
+}
+
+func TestExprPrecedenceAndAssociativity(t *testing.T) {
+    tests := []struct {
+        source string
+        want   float64
+    }{
+        {"1 + 2 * 3", 7},
+        {"(1 + 2) * 3", 9},
+        {"10 - 4 - 3", 3},   // Left associative
+        {"24 / 4 / 2", 3},   // Left associative
+        {"2 * 3 + 4 * 5", 26},
+        {"-x * 2", -8},
+        {"- -x", 4},
+        {"x - -y", 6},
+        {"1 + 2 > 2 + 0", 1},        // Arithmetic binds tighter than comparison
+        {"1 > 2 || 3 > 2 && 0", 0},  // && binds tighter than ||
+        {"(1 > 2 || 3 > 2) && 1", 1},
+        {"!0 && 0", 0},              // ! binds tighter than &&
+        {"!(x > y)", 0},
+        {"x == 4 && y != 4", 1},
+        {"abs(-3) + max(1, 2) * min(4, 5)", 11},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.source, func(t *testing.T) {
+            v, err := evalExpr(t, tt.source)
+            if err != nil {
+                t.Fatalf("Eval: %v", err)
+            }
+            if v.IsStr || v.Num != tt.want {
+                t.Errorf("= %s, want %v", v, tt.want)
+            }
+        })
+    }
+}
+
+func TestExprNaNAndDivisionByZero(t *testing.T) {
+    tests := []struct {
+        source  string
+        want    float64
+        wantNaN bool
+    }{
+        {source: "x / 0", wantNaN: true},
+        {source: "0 / 0", wantNaN: true},
+        {source: "(x / 0) + 1", wantNaN: true},
+        {source: "x / 0 > 0", want: 0},
+        {source: "x / 0 < 0", want: 0},
+        {source: "nan == nan", want: 0},
+        {source: "nan != nan", want: 1},
+        {source: "nan && 1", want: 0},
+        {source: "1 && nan", want: 0},
+        {source: "nan || 1", want: 1},
+        {source: "nan || 0", want: 0},
+        {source: "!nan", want: 1},
+        {source: "!(x / 0)", want: 1},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.source, func(t *testing.T) {
+            v, err := evalExpr(t, tt.source)
+            if err != nil {
+                t.Fatalf("Eval: %v", err)
+            }
+            if tt.wantNaN {
+                if !math.IsNaN(v.Num) {
+                    t.Errorf("= %s, want NaN", v)
+                }
+                return
+            }
+            if v.Num != tt.want {
+                t.Errorf("= %s, want %v", v, tt.want)
+            }
+        })
+    }
+}
+
+func TestExprShortCircuit(t *testing.T) {
+    // The right side reads a variable the environment doesn't have
+    for _, source := range []string{"0 && missing > 1", "1 || missing > 1"} {
+        if _, err := evalExpr(t, source); err != nil {
+            t.Errorf("%s: right side evaluated: %v", source, err)
+        }
+    }
+    if _, err := evalExpr(t, "1 && missing > 1"); err == nil {
+        t.Error("missing variable on the evaluated side did not fail")
+    }
+}
+
+func TestExprStringTypes(t *testing.T) {
+    tests := []struct {
+        source  string
+        want    float64
+        wantErr string
+    }{
+        {source: `regime == "TRENDING"`, want: 1},
+        {source: `regime != 'RANGING'`, want: 1},
+        {source: `regime == 1`, wantErr: "cannot compare"},
+        {source: `x > "5"`, wantErr: "cannot compare"},
+        {source: `regime + "X"`, wantErr: "not supported on strings"},
+        {source: `regime < "Z"`, wantErr: "not supported on strings"},
+        {source: `-regime`, wantErr: "cannot negate string"},
+        {source: `abs(regime)`, wantErr: "argument 1 must be a number"},
+        {source: `regime && 1`, want: 1}, // Non-empty strings are truthy
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.source, func(t *testing.T) {
+            v, err := evalExpr(t, tt.source)
+            if tt.wantErr != "" {
+                if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+                    t.Errorf("err = %v, want %q", err, tt.wantErr)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("Eval: %v", err)
+            }
+            if v.Num != tt.want {
+                t.Errorf("= %s, want %v", v, tt.want)
+            }
+        })
+    }
+}
+
+func TestExprCompileErrors(t *testing.T) {
+    tests := []struct {
+        source  string
+        wantErr string
+    }{
+        {"foo(1)", `unknown function "foo" (available: abs, adx,`},
+        {"unknown_var > 1", `unknown variable "unknown_var" (available: missing, nan, regime, x, y)`},
+        {"rsi()", "rsi expects 1 argument(s) plus an optional timeframe, got 0"},
+        {"rsi(14, 2, \"1h\")", "rsi expects 1 argument(s) plus an optional timeframe, got 3"},
+        {"abs(1, 2)", "abs expects 1 argument(s), got 2"},
+        {"max(1)", "max expects 2 argument(s), got 1"},
+        {"macd(1)", `macd: timeframe must be a string literal like "15m"`},
+        {"macd(1, \"1h\")", "macd expects 0 argument(s) plus an optional timeframe, got 2"},
+        {"rsi(14, \"7m\")", `rsi: unknown timeframe "7m"`},
+        {"rsi(14, \"1H\")", `rsi: unknown timeframe "1H"`},
+        {"rsi(14, regime)", `rsi: timeframe must be a string literal like "15m"`},
+        {"rsi(x)", "rsi: argument 1 must be a number"},
+        {"rsi(0)", "rsi: argument 1 must be positive"},
+        {"ema(600, \"1h\")", "ema needs 1201 bars, more than the 1000 Binance returns"},
+        {"1 < 2 < 3", `unexpected "<"`},
+        {"(1 + 2", `expected ")" but found end of expression`},
+        {"1 +", "unexpected end of expression"},
+        {"x > 1 $", `unexpected character '$'`},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.source, func(t *testing.T) {
+            _, err := CompileExpr(tt.source, exprTestVars)
+            if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+                t.Errorf("err = %v, want it to contain %q", err, tt.wantErr)
+            }
+        })
+    }
+}
+
+func TestExprWarmupBars(t *testing.T) {
+    tests := []struct {
+        source string
+        want   map[string]int
+    }{
+        {`ema(200, "1h") < x`, map[string]int{"1h": 401}},
+        {`rsi(14) < 30`, map[string]int{"1m": 43}},
+        {`sma(50, "15m") > sma(20, "15m")`, map[string]int{"15m": 51}},
+        {`rsi(14, "5m") < 30 && ema(100, "5m") > 0 && macd("4h") > 0`, map[string]int{"5m": 201, "4h": 100}},
+        {`bb_lower(20, 2, "1d") > 0`, map[string]int{"1d": 21}},
+        {`x > y`, map[string]int{}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.source, func(t *testing.T) {
+            expr, err := CompileExpr(tt.source, map[string]bool{"x": true, "y": true})
+            if err != nil {
+                t.Fatalf("CompileExpr: %v", err)
+            }
+            got := expr.Timeframes()
+            if len(got) != len(tt.want) {
+                t.Fatalf("timeframes = %v, want %v", got, tt.want)
+            }
+            for tf, bars := range tt.want {
+                if got[tf] != bars {
+                    t.Errorf("%s bars = %d, want %d", tf, got[tf], bars)
+                }
+            }
+        })
+    }
+}
+
+func TestExprIndicatorWithoutKlines(t *testing.T) {
+    expr, err := CompileExpr(`rsi(14, "1h") < 30`, exprTestVars)
+    if err != nil {
+        t.Fatalf("CompileExpr: %v", err)
+    }
+    if _, err := expr.Eval(exprTestEnv()); err == nil || !strings.Contains(err.Error(), "no 1h klines available") {
+        t.Errorf("err = %v, want missing klines", err)
+    }
+
+    v, err := evalExpr(t, `rsi(14) > 0 && close() > 0`)
+    if err != nil || v.Num != 1 {
+        t.Errorf("indicator on 1m klines = %s, %v", v, err)
+    }
+}
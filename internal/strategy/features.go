@@ -0,0 +1,125 @@
+// File: internal/strategy/features.go
+// ============================================
+package strategy
+
+import (
+    "math"
+    "strings"
+)
+
+// ExtractFeatures builds the model feature vector from a signal context.
+// Price based values are expressed as percentages so they compare across
+// coins. Non-finite values are left out and treated as missing by models.
+//
+// The same map is written by the feature export, so a model trained on the
+// export can be loaded back without any translation.
+func ExtractFeatures(ctx *SignalContext, eval RuleEvaluation) map[string]float64 {
+    features := map[string]float64{}
+    set := func(name string, value float64) {
+        if !math.IsNaN(value) && !math.IsInf(value, 0) {
+            features[name] = value
+        }
+    }
+    pct := func(value, base float64) float64 {
+        if base == 0 {
+            return math.NaN()
+        }
+        return value / base * 100
+    }
+    flag := func(b bool) float64 {
+        if b {
+            return 1
+        }
+        return 0
+    }
+
+    price := ctx.Ticker.LastPrice
+
+    // Ticker and volume
+    set("price_change", ctx.Ticker.PriceChangePercent)
+    set("log_quote_volume", math.Log10(math.Max(NotionalVolume(ctx.Ticker), 1)))
+    set("volume_ratio", ctx.VolumeRatio)
+    set("volume_spike", flag(ctx.VolumeSpike))
+    set("volume_strength", ctx.VolumeStrength)
+    if ctx.RVOL > 0 {
+        set("rvol", ctx.RVOL)
+    }
+    set("accumulation", flag(ctx.VolumeProfile == "ACCUMULATION"))
+
+    // 1m indicators
+    set("rsi", ctx.RSI)
+    set("macd_pct", pct(ctx.MACD, price))
+    set("macd_hist_pct", pct(ctx.MACDHistogram, price))
+    set("ema_spread_pct", pct(ctx.EMA12-ctx.EMA26, ctx.EMA26))
+    set("sma20_dist_pct", pct(price-ctx.SMA20, ctx.SMA20))
+    if width := ctx.UpperBB - ctx.LowerBB; width > 0 {
+        set("bb_position", (price-ctx.LowerBB)/width)
+        set("bb_width_pct", pct(width, ctx.MiddleBB))
+    }
+    set("atr_pct", pct(ctx.ATR, price))
+
+    // Regime
+    set("regime_confidence", ctx.RegimeConfidence)
+    for _, regime := range []string{"TRENDING", "RANGING", "VOLATILE"} {
+        set("regime_"+strings.ToLower(regime), flag(ctx.Regime == regime))
+    }
+
+    // Multi-timeframe
+    set("mtf_score", ctx.MTFScore)
+    bullishDivergences, bearishDivergences := 0, 0
+    for _, a := range ctx.MTFAnalyses {
+        trend := 0.0
+        switch a.Trend {
+        case "BULLISH":
+            trend = a.Strength
+        case "BEARISH":
+            trend = -a.Strength
+        }
+        set("tf_"+a.Timeframe+"_trend", trend)
+        set("tf_"+a.Timeframe+"_rsi", a.RSI)
+        for _, d := range a.Divergences {
+            if d.Direction == "BULLISH" {
+                bullishDivergences++
+            } else {
+                bearishDivergences++
+            }
+        }
+    }
+    set("bullish_divergences", float64(bullishDivergences))
+    set("bearish_divergences", float64(bearishDivergences))
+
+    // Candlestick patterns
+    set("bullish_patterns", float64(len(FilterPatterns(ctx.Patterns, "BULLISH", 0))))
+    set("bearish_patterns", float64(len(FilterPatterns(ctx.Patterns, "BEARISH", 0))))
+
+    // Market state
+    if m := ctx.Market; m != nil {
+        btcTrend := 0.0
+        switch m.BTCTrend {
+        case "BULLISH":
+            btcTrend = m.BTCStrength
+        case "BEARISH":
+            btcTrend = -m.BTCStrength
+        }
+        set("btc_trend", btcTrend)
+        set("btc_change", m.BTCChange)
+        set("market_ad_ratio", m.AdvanceDecline)
+        set("market_above_vwap", m.AboveVWAP)
+        set("market_volume_change", m.VolumeChange)
+        set("market_risk_off", flag(m.RiskOff))
+    }
+
+    // Anomaly check
+    if a := ctx.Anomaly; a != nil {
+        set("anomaly_flags", float64(len(a.Flags)))
+        set("anomaly_candle_atr", a.CandleATR)
+        set("anomaly_top_trade_share", a.TopTradeShare)
+        set("anomaly_spread_pct", a.SpreadPercent)
+    }
+
+    // Rule engine output
+    set("rule_strength", eval.Strength)
+    set("rule_rejected", flag(eval.Rejected))
+
+    return features
+}
@@ -7,67 +7,13 @@ import (
     "binance-trading-bot/pkg/types"
 )
 
-// CalculateRSI - Relative Strength Index (FIXED VERSION)
+// CalculateRSI - Relative Strength Index (Wilder smoothing)
 func CalculateRSI(prices []float64, period int) float64 {
-    if len(prices) < period+1 {
-        return 50.0
+    rsi := NewRSI(period)
+    for _, p := range prices {
+        rsi.Update(p)
     }
-    
-    // Calculate price changes
-    gains := make([]float64, 0)
-    losses := make([]float64, 0)
-    
-    for i := 1; i < len(prices); i++ {
-        change := prices[i] - prices[i-1]
-        if change > 0 {
-            gains = append(gains, change)
-            losses = append(losses, 0)
-        } else {
-            gains = append(gains, 0)
-            losses = append(losses, math.Abs(change))
-        }
-    }
-    
-    if len(gains) < period {
-        return 50.0
-    }
-    
-    // First RS calculation uses SMA
-    avgGain := 0.0
-    avgLoss := 0.0
-    for i := 0; i < period; i++ {
-        avgGain += gains[i]
-        avgLoss += losses[i]
-    }
-    avgGain /= float64(period)
-    avgLoss /= float64(period)
-    
-    // Subsequent calculations use smoothed averages (Wilder's smoothing)
-    for i := period; i < len(gains); i++ {
-        avgGain = (avgGain*float64(period-1) + gains[i]) / float64(period)
-        avgLoss = (avgLoss*float64(period-1) + losses[i]) / float64(period)
-    }
-    
-    // Handle edge cases
-    if avgLoss == 0 {
-        if avgGain == 0 {
-            return 50.0  // No movement
-        }
-        return 100.0  // All gains, no losses
-    }
-    
-    rs := avgGain / avgLoss
-    rsi := 100.0 - (100.0 / (1.0 + rs))
-    
-    // Safety bounds
-    if rsi < 0 {
-        rsi = 0
-    }
-    if rsi > 100 {
-        rsi = 100
-    }
-    
-    return rsi
+    return rsi.Value()
 }
 
 // CalculateSMA - Simple Moving Average
@@ -85,42 +31,20 @@ func CalculateSMA(prices []float64, period int) float64 {
 
 // CalculateEMA - Exponential Moving Average
 func CalculateEMA(prices []float64, period int) float64 {
-    if len(prices) < period {
-        return 0
+    ema := NewEMA(period)
+    for _, p := range prices {
+        ema.Update(p)
     }
-    
-    multiplier := 2.0 / float64(period+1)
-    ema := CalculateSMA(prices[:period], period)
-    
-    for i := period; i < len(prices); i++ {
-        ema = (prices[i]-ema)*multiplier + ema
-    }
-    return ema
+    return ema.Value()
 }
 
 // CalculateMACD - Moving Average Convergence Divergence
 func CalculateMACD(prices []float64) (macd, signal, histogram float64) {
-    if len(prices) < 26 {
-        return 0, 0, 0
-    }
-    
-    ema12 := CalculateEMA(prices, 12)
-    ema26 := CalculateEMA(prices, 26)
-    macd = ema12 - ema26
-    
-    macdValues := make([]float64, 0)
-    for i := 26; i < len(prices); i++ {
-        tempPrices := prices[:i+1]
-        tempEMA12 := CalculateEMA(tempPrices, 12)
-        tempEMA26 := CalculateEMA(tempPrices, 26)
-        macdValues = append(macdValues, tempEMA12-tempEMA26)
+    m := NewMACD()
+    for _, p := range prices {
+        m.Update(p)
     }
-    
-    if len(macdValues) >= 9 {
-        signal = CalculateEMA(macdValues, 9)
-        histogram = macd - signal
-    }
-    return macd, signal, histogram
+    return m.Value()
 }
 
 // CalculateBollingerBands - Returns upper, middle, lower bands
@@ -129,20 +53,11 @@ func CalculateBollingerBands(prices []float64, period int, stdDev float64) (uppe
         return 0, 0, 0
     }
     
-    middle = CalculateSMA(prices, period)
-    
-    // Calculate standard deviation
-    variance := 0.0
-    for i := len(prices) - period; i < len(prices); i++ {
-        variance += math.Pow(prices[i]-middle, 2)
+    bb := NewBollinger(period, stdDev)
+    for _, p := range prices[len(prices)-period:] {
+        bb.Update(p)
     }
-    variance = variance / float64(period)
-    stdDeviation := math.Sqrt(variance)
-    
-    upper = middle + (stdDev * stdDeviation)
-    lower = middle - (stdDev * stdDeviation)
-    
-    return upper, middle, lower
+    return bb.Value()
 }
 
 // CalculateATR - Average True Range (volatility indicator)
@@ -151,18 +66,11 @@ func CalculateATR(klines []types.Kline, period int) float64 {
         return 0
     }
     
-    trueRanges := make([]float64, 0)
-    
-    for i := 1; i < len(klines); i++ {
-        highLow := klines[i].High - klines[i].Low
-        highClose := math.Abs(klines[i].High - klines[i-1].Close)
-        lowClose := math.Abs(klines[i].Low - klines[i-1].Close)
-        
-        tr := math.Max(highLow, math.Max(highClose, lowClose))
-        trueRanges = append(trueRanges, tr)
+    atr := NewATR(period)
+    for _, k := range klines[len(klines)-period-1:] {
+        atr.Update(k)
     }
-    
-    return CalculateSMA(trueRanges, period)
+    return atr.Value()
 }
 
 // CalculateStochastic - Stochastic Oscillator
@@ -171,30 +79,11 @@ func CalculateStochastic(klines []types.Kline, period int) (k, d float64) {
         return 50, 50
     }
     
-    recentKlines := klines[len(klines)-period:]
-    
-    high := recentKlines[0].High
-    low := recentKlines[0].Low
-    
-    for _, kline := range recentKlines {
-        if kline.High > high {
-            high = kline.High
-        }
-        if kline.Low < low {
-            low = kline.Low
-        }
+    stoch := NewStochastic(period)
+    for _, kline := range klines[len(klines)-period:] {
+        stoch.Update(kline)
     }
-    
-    currentClose := klines[len(klines)-1].Close
-    
-    if high-low == 0 {
-        return 50, 50
-    }
-    
-    k = ((currentClose - low) / (high - low)) * 100
-    d = k // Simplified - in production, calculate 3-period SMA of K
-    
-    return k, d
+    return stoch.Value()
 }
 
 // DetectVolumeSpike - Returns true if volume is significantly above average
@@ -232,48 +121,70 @@ func DetectVolumeSpike(volumes []float64, currentVolume float64) (bool, float64)
 
 // CalculateVWAP - Volume Weighted Average Price
 func CalculateVWAP(klines []types.Kline) float64 {
-    if len(klines) == 0 {
-        return 0
-    }
-    
-    totalVolume := 0.0
-    vwap := 0.0
-    
+    vwap := NewVWAP()
     for _, k := range klines {
-        typicalPrice := (k.High + k.Low + k.Close) / 3
-        totalVolume += k.Volume
-        vwap += typicalPrice * k.Volume
-    }
-    
-    if totalVolume == 0 {
-        return 0
+        vwap.Update(k)
     }
-    
-    return vwap / totalVolume
+    return vwap.Value()
+}
+
+// TrendIndicators are the indicator values trend detection scores, computed
+// in one pass so callers that also report them don't recompute
+type TrendIndicators struct {
+    Closes        []float64
+    Volumes       []float64
+    SMA20         float64
+    SMA50         float64
+    RSI           float64
+    MACD          float64
+    MACDSignal    float64
+    MACDHistogram float64
+    UpperBB       float64
+    MiddleBB      float64
+    LowerBB       float64
+}
+
+// ComputeTrendIndicators runs RSI(14), MACD and Bollinger(20, 2) over the
+// closes in a single pass
+func ComputeTrendIndicators(klines []types.Kline) TrendIndicators {
+    ind := TrendIndicators{
+        Closes:  make([]float64, len(klines)),
+        Volumes: make([]float64, len(klines)),
+    }
+    rsi, macd, bb := NewRSI(14), NewMACD(), NewBollinger(20, 2.0)
+    for i, k := range klines {
+        ind.Closes[i] = k.Close
+        ind.Volumes[i] = k.Volume
+        rsi.Update(k.Close)
+        macd.Update(k.Close)
+        bb.Update(k.Close)
+    }
+    
+    ind.SMA20 = CalculateSMA(ind.Closes, 20)
+    ind.SMA50 = CalculateSMA(ind.Closes, 50)
+    ind.RSI = rsi.Value()
+    ind.MACD, ind.MACDSignal, ind.MACDHistogram = macd.Value()
+    ind.UpperBB, ind.MiddleBB, ind.LowerBB = bb.Value()
+    return ind
 }
 
 // DetectTrend - Enhanced trend detection with strength
 func DetectTrend(klines []types.Kline) (string, float64) {
-    if len(klines) < 20 {
+    return DetectTrendFrom(ComputeTrendIndicators(klines))
+}
+
+// DetectTrendFrom scores the trend from precomputed indicators
+func DetectTrendFrom(ind TrendIndicators) (string, float64) {
+    closes, volumes := ind.Closes, ind.Volumes
+    if len(closes) < 20 {
         return "NEUTRAL", 0.5
     }
     
-    closes := make([]float64, len(klines))
-    volumes := make([]float64, len(klines))
-    
-    for i, k := range klines {
-        closes[i] = k.Close
-        volumes[i] = k.Volume
-    }
-    
-    sma20 := CalculateSMA(closes, 20)
-    sma50 := CalculateSMA(closes, 50)
+    sma20, sma50 := ind.SMA20, ind.SMA50
     currentPrice := closes[len(closes)-1]
-    rsi := CalculateRSI(closes, 14)
-    macd, signal, _ := CalculateMACD(closes)
-    
-    // Bollinger Bands
-    upperBB, _, lowerBB := CalculateBollingerBands(closes, 20, 2.0)
+    rsi := ind.RSI
+    macd, signal := ind.MACD, ind.MACDSignal
+    upperBB, lowerBB := ind.UpperBB, ind.LowerBB
     
     // Volume analysis
     currentVolume := volumes[len(volumes)-1]
@@ -345,27 +256,6 @@ func DetectTrend(klines []types.Kline) (string, float64) {
     return "NEUTRAL", 0.5
 }
 
-// CalculateSupportResistance - Find support and resistance levels
-func CalculateSupportResistance(klines []types.Kline) (support, resistance float64) {
-    if len(klines) < 20 {
-        return 0, 0
-    }
-    
-    support = klines[0].Low
-    resistance = klines[0].High
-    
-    for _, k := range klines {
-        if k.Low < support {
-            support = k.Low
-        }
-        if k.High > resistance {
-            resistance = k.High
-        }
-    }
-    
-    return support, resistance
-}
-
 // CalculateMomentumScore - Composite momentum score (0-100)
 func CalculateMomentumScore(prices []float64, volumes []float64) float64 {
     if len(prices) < 20 || len(volumes) < 20 {
@@ -420,21 +310,16 @@ func DetectMarketRegime(klines []types.Kline) (regime string, confidence float64
     // ATR as % of price (volatility measure)
     volatility := (atr / currentPrice) * 100
     
-    // Count closes above/below SMA for trend consistency
-    aboveSMA := 0
-    for i := len(closes) - 20; i < len(closes); i++ {
-        if closes[i] > sma {
-            aboveSMA++
-        }
-    }
-    consistency := float64(aboveSMA) / 20.0
+    // ADX measures trend strength regardless of direction
+    adx, _, _ := CalculateADX(klines, 14)
     
     // Classify regime
     if volatility > 5.0 {
         return "VOLATILE", 0.8
-    } else if consistency > 0.7 || consistency < 0.3 {
-        return "TRENDING", math.Abs(consistency-0.5) * 2
-    } else if deviation < 2.0 {
+    } else if adx >= 25 {
+        // ADX 25 = 62.5% confidence, 40+ = 100%
+        return "TRENDING", math.Min(adx/40, 1.0)
+    } else if adx < 20 && deviation < 2.0 {
         return "RANGING", 0.7
     }
     
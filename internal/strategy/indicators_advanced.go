@@ -0,0 +1,414 @@
+// File: internal/strategy/indicators_advanced.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "math"
+)
+
+// trueRange of kline i (needs i >= 1)
+func trueRange(klines []types.Kline, i int) float64 {
+    highLow := klines[i].High - klines[i].Low
+    highClose := math.Abs(klines[i].High - klines[i-1].Close)
+    lowClose := math.Abs(klines[i].Low - klines[i-1].Close)
+    return math.Max(highLow, math.Max(highClose, lowClose))
+}
+
+// wilderATRSeries - ATR with Wilder smoothing (RMA), as used by ADX and SuperTrend.
+// The first value is at index period.
+func wilderATRSeries(klines []types.Kline, period int) []float64 {
+    series := nanSeries(len(klines))
+    if period < 1 || len(klines) < period+1 {
+        return series
+    }
+
+    sum := 0.0
+    for i := 1; i <= period; i++ {
+        sum += trueRange(klines, i)
+    }
+    atr := sum / float64(period)
+    series[period] = atr
+
+    for i := period + 1; i < len(klines); i++ {
+        atr = (atr*float64(period-1) + trueRange(klines, i)) / float64(period)
+        series[i] = atr
+    }
+    return series
+}
+
+// highestHigh and lowestLow over the period bars ending at index end (inclusive)
+func highLowRange(klines []types.Kline, end, period int) (high, low float64) {
+    high = math.Inf(-1)
+    low = math.Inf(1)
+    for i := end - period + 1; i <= end; i++ {
+        high = math.Max(high, klines[i].High)
+        low = math.Min(low, klines[i].Low)
+    }
+    return high, low
+}
+
+func typicalPrice(k types.Kline) float64 {
+    return (k.High + k.Low + k.Close) / 3
+}
+
+// ============================================
+// ADX / DMI
+// ============================================
+
+// CalculateADX - Average Directional Index with +DI and -DI (Wilder)
+func CalculateADX(klines []types.Kline, period int) (adx, plusDI, minusDI float64) {
+    adxs, plus, minus := ADXSeries(klines, period)
+    if len(klines) == 0 {
+        return 0, 0, 0
+    }
+    last := len(klines) - 1
+    if math.IsNaN(plus[last]) {
+        return 0, 0, 0
+    }
+    if math.IsNaN(adxs[last]) {
+        return 0, plus[last], minus[last]
+    }
+    return adxs[last], plus[last], minus[last]
+}
+
+// ADXSeries - ADX, +DI and -DI for every bar. DI values start at index period,
+// ADX at index 2*period-1.
+func ADXSeries(klines []types.Kline, period int) (adx, plusDI, minusDI []float64) {
+    n := len(klines)
+    adx, plusDI, minusDI = nanSeries(n), nanSeries(n), nanSeries(n)
+    if period < 1 || n < period+1 {
+        return adx, plusDI, minusDI
+    }
+
+    directional := func(i int) (plusDM, minusDM float64) {
+        up := klines[i].High - klines[i-1].High
+        down := klines[i-1].Low - klines[i].Low
+        if up > down && up > 0 {
+            plusDM = up
+        }
+        if down > up && down > 0 {
+            minusDM = down
+        }
+        return plusDM, minusDM
+    }
+
+    smTR, smPlus, smMinus := 0.0, 0.0, 0.0
+    for i := 1; i <= period; i++ {
+        p, m := directional(i)
+        smTR += trueRange(klines, i)
+        smPlus += p
+        smMinus += m
+    }
+
+    dxSum := 0.0
+    dxCount := 0
+    for i := period; i < n; i++ {
+        if i > period {
+            p, m := directional(i)
+            smTR = smTR - smTR/float64(period) + trueRange(klines, i)
+            smPlus = smPlus - smPlus/float64(period) + p
+            smMinus = smMinus - smMinus/float64(period) + m
+        }
+
+        if smTR == 0 {
+            plusDI[i], minusDI[i] = 0, 0
+        } else {
+            plusDI[i] = 100 * smPlus / smTR
+            minusDI[i] = 100 * smMinus / smTR
+        }
+
+        dx := 0.0
+        if diSum := plusDI[i] + minusDI[i]; diSum > 0 {
+            dx = 100 * math.Abs(plusDI[i]-minusDI[i]) / diSum
+        }
+
+        if dxCount < period {
+            dxSum += dx
+            dxCount++
+            if dxCount == period {
+                adx[i] = dxSum / float64(period)
+            }
+        } else {
+            adx[i] = (adx[i-1]*float64(period-1) + dx) / float64(period)
+        }
+    }
+    return adx, plusDI, minusDI
+}
+
+// ============================================
+// SuperTrend
+// ============================================
+
+// CalculateSuperTrend - Returns the SuperTrend line and whether the trend is up
+func CalculateSuperTrend(klines []types.Kline, period int, multiplier float64) (value float64, bullish bool) {
+    values, trend := SuperTrendSeries(klines, period, multiplier)
+    if len(values) == 0 || math.IsNaN(values[len(values)-1]) {
+        return 0, false
+    }
+    return values[len(values)-1], trend[len(trend)-1]
+}
+
+// SuperTrendSeries - SuperTrend line and direction for every bar (from index period)
+func SuperTrendSeries(klines []types.Kline, period int, multiplier float64) (values []float64, bullish []bool) {
+    n := len(klines)
+    values = nanSeries(n)
+    bullish = make([]bool, n)
+    atr := wilderATRSeries(klines, period)
+
+    var finalUpper, finalLower float64
+    up := true
+    started := false
+
+    for i := 0; i < n; i++ {
+        if math.IsNaN(atr[i]) {
+            continue
+        }
+        hl2 := (klines[i].High + klines[i].Low) / 2
+        basicUpper := hl2 + multiplier*atr[i]
+        basicLower := hl2 - multiplier*atr[i]
+
+        if !started {
+            finalUpper, finalLower = basicUpper, basicLower
+            up = klines[i].Close >= hl2
+            started = true
+        } else {
+            prevClose := klines[i-1].Close
+            if basicUpper < finalUpper || prevClose > finalUpper {
+                finalUpper = basicUpper
+            }
+            if basicLower > finalLower || prevClose < finalLower {
+                finalLower = basicLower
+            }
+            if up && klines[i].Close < finalLower {
+                up = false
+            } else if !up && klines[i].Close > finalUpper {
+                up = true
+            }
+        }
+
+        bullish[i] = up
+        if up {
+            values[i] = finalLower
+        } else {
+            values[i] = finalUpper
+        }
+    }
+    return values, bullish
+}
+
+// ============================================
+// Ichimoku Cloud
+// ============================================
+
+// IchimokuCloud holds the Ichimoku lines as they apply to the latest bar
+type IchimokuCloud struct {
+    Tenkan        float64 // Conversion line (9)
+    Kijun         float64 // Base line (26)
+    SpanA         float64 // Leading span A plotted at the current bar
+    SpanB         float64 // Leading span B plotted at the current bar
+    ChikouBullish bool    // Close is above the close 26 bars ago
+    Valid         bool
+}
+
+// AboveCloud reports whether price is above both leading spans
+func (c IchimokuCloud) AboveCloud(price float64) bool {
+    return c.Valid && price > math.Max(c.SpanA, c.SpanB)
+}
+
+// BelowCloud reports whether price is below both leading spans
+func (c IchimokuCloud) BelowCloud(price float64) bool {
+    return c.Valid && price < math.Min(c.SpanA, c.SpanB)
+}
+
+// CalculateIchimoku - Standard 9/26/52 Ichimoku; needs 78 klines for the cloud
+func CalculateIchimoku(klines []types.Kline) IchimokuCloud {
+    const tenkanPeriod, kijunPeriod, spanBPeriod, displacement = 9, 26, 52, 26
+
+    n := len(klines)
+    if n < spanBPeriod+displacement {
+        return IchimokuCloud{}
+    }
+
+    mid := func(end, period int) float64 {
+        high, low := highLowRange(klines, end, period)
+        return (high + low) / 2
+    }
+
+    last := n - 1
+    origin := last - displacement // Spans plotted at the current bar were computed here
+
+    return IchimokuCloud{
+        Tenkan:        mid(last, tenkanPeriod),
+        Kijun:         mid(last, kijunPeriod),
+        SpanA:         (mid(origin, tenkanPeriod) + mid(origin, kijunPeriod)) / 2,
+        SpanB:         mid(origin, spanBPeriod),
+        ChikouBullish: klines[last].Close > klines[origin].Close,
+        Valid:         true,
+    }
+}
+
+// ============================================
+// Volume based
+// ============================================
+
+// OBVSeries - On-Balance Volume for every bar, starting at 0
+func OBVSeries(klines []types.Kline) []float64 {
+    series := make([]float64, len(klines))
+    for i := 1; i < len(klines); i++ {
+        series[i] = series[i-1]
+        if klines[i].Close > klines[i-1].Close {
+            series[i] += klines[i].Volume
+        } else if klines[i].Close < klines[i-1].Close {
+            series[i] -= klines[i].Volume
+        }
+    }
+    return series
+}
+
+// CalculateOBV - Latest On-Balance Volume
+func CalculateOBV(klines []types.Kline) float64 {
+    if len(klines) == 0 {
+        return 0
+    }
+    return Last(OBVSeries(klines))
+}
+
+// CalculateMFI - Money Flow Index (0-100)
+func CalculateMFI(klines []types.Kline, period int) float64 {
+    if period < 1 || len(klines) < period+1 {
+        return 50
+    }
+
+    positive, negative := 0.0, 0.0
+    for i := len(klines) - period; i < len(klines); i++ {
+        tp := typicalPrice(klines[i])
+        prevTP := typicalPrice(klines[i-1])
+        flow := tp * klines[i].Volume
+        if tp > prevTP {
+            positive += flow
+        } else if tp < prevTP {
+            negative += flow
+        }
+    }
+
+    if negative == 0 {
+        if positive == 0 {
+            return 50
+        }
+        return 100
+    }
+    return 100 - 100/(1+positive/negative)
+}
+
+// ============================================
+// Channels and oscillators
+// ============================================
+
+// CalculateKeltnerChannels - EMA of close +/- multiplier * ATR
+func CalculateKeltnerChannels(klines []types.Kline, period int, multiplier float64) (upper, middle, lower float64) {
+    if len(klines) < period+1 {
+        return 0, 0, 0
+    }
+    middle = CalculateEMA(ClosePrices(klines), period)
+    atr := CalculateATR(klines, period)
+    return middle + multiplier*atr, middle, middle - multiplier*atr
+}
+
+// CalculateCCI - Commodity Channel Index
+func CalculateCCI(klines []types.Kline, period int) float64 {
+    if period < 1 || len(klines) < period {
+        return 0
+    }
+
+    tps := make([]float64, period)
+    for i, k := range klines[len(klines)-period:] {
+        tps[i] = typicalPrice(k)
+    }
+    mean := CalculateSMA(tps, period)
+
+    meanDeviation := 0.0
+    for _, tp := range tps {
+        meanDeviation += math.Abs(tp - mean)
+    }
+    meanDeviation /= float64(period)
+
+    if meanDeviation == 0 {
+        return 0
+    }
+    return (tps[period-1] - mean) / (0.015 * meanDeviation)
+}
+
+// CalculateWilliamsR - Williams %R (-100 to 0)
+func CalculateWilliamsR(klines []types.Kline, period int) float64 {
+    if period < 1 || len(klines) < period {
+        return -50
+    }
+    high, low := highLowRange(klines, len(klines)-1, period)
+    if high-low == 0 {
+        return -50
+    }
+    return (high - klines[len(klines)-1].Close) / (high - low) * -100
+}
+
+// CalculateParabolicSAR - Returns the SAR for the latest bar and whether it is below price
+func CalculateParabolicSAR(klines []types.Kline, step, maxStep float64) (sar float64, bullish bool) {
+    values, trend := ParabolicSARSeries(klines, step, maxStep)
+    if len(values) < 2 {
+        return 0, false
+    }
+    return values[len(values)-1], trend[len(trend)-1]
+}
+
+// ParabolicSARSeries - Parabolic SAR and direction for every bar (from index 1)
+func ParabolicSARSeries(klines []types.Kline, step, maxStep float64) (values []float64, bullish []bool) {
+    n := len(klines)
+    values = nanSeries(n)
+    bullish = make([]bool, n)
+    if n < 2 {
+        return values, bullish
+    }
+
+    up := klines[1].Close >= klines[0].Close
+    af := step
+    sar, ep := klines[0].Low, klines[0].High
+    if !up {
+        sar, ep = klines[0].High, klines[0].Low
+    }
+
+    for i := 1; i < n; i++ {
+        sar = sar + af*(ep-sar)
+
+        if up {
+            // SAR can't be above the prior two lows
+            sar = math.Min(sar, klines[i-1].Low)
+            if i >= 2 {
+                sar = math.Min(sar, klines[i-2].Low)
+            }
+            if klines[i].Low < sar {
+                up = false
+                sar, ep, af = ep, klines[i].Low, step
+            } else if klines[i].High > ep {
+                ep = klines[i].High
+                af = math.Min(af+step, maxStep)
+            }
+        } else {
+            // SAR can't be below the prior two highs
+            sar = math.Max(sar, klines[i-1].High)
+            if i >= 2 {
+                sar = math.Max(sar, klines[i-2].High)
+            }
+            if klines[i].High > sar {
+                up = true
+                sar, ep, af = ep, klines[i].High, step
+            } else if klines[i].Low < ep {
+                ep = klines[i].Low
+                af = math.Min(af+step, maxStep)
+            }
+        }
+
+        values[i] = sar
+        bullish[i] = up
+    }
+    return values, bullish
+}
@@ -0,0 +1,198 @@
+// File: internal/strategy/indicators_advanced_test.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "math"
+    "testing"
+)
+
+// referenceKlines is a 10-bar fixture. The expected values in these tests were
+// worked out independently from the textbook definitions (Wilder's DMI/ADX and
+// Parabolic SAR, Lambert's CCI, ...) rather than produced by the code under test.
+func referenceKlines() []types.Kline {
+    highs := []float64{10, 11, 12, 11.5, 12.5, 13, 12, 13.5, 14, 13}
+    lows := []float64{9, 10, 10.5, 10, 11, 12, 11, 12, 12.5, 12}
+    closes := []float64{9.5, 10.8, 11, 10.5, 12.2, 12.5, 11.5, 13.2, 13, 12.2}
+    volumes := []float64{100, 150, 120, 130, 160, 170, 110, 180, 200, 90}
+
+    klines := make([]types.Kline, len(closes))
+    for i := range klines {
+        klines[i] = types.Kline{Open: closes[i], High: highs[i], Low: lows[i], Close: closes[i], Volume: volumes[i]}
+    }
+    return klines
+}
+
+// linearKlines rises by 1 every bar: High i+1, Low i, Close i+0.5
+func linearKlines(n int) []types.Kline {
+    klines := make([]types.Kline, n)
+    for i := range klines {
+        f := float64(i)
+        klines[i] = types.Kline{Open: f + 0.5, High: f + 1, Low: f, Close: f + 0.5, Volume: 100}
+    }
+    return klines
+}
+
+func assertClose(t *testing.T, name string, got, want float64) {
+    t.Helper()
+    if math.IsNaN(want) {
+        if !math.IsNaN(got) {
+            t.Errorf("%s = %v, want NaN (not ready)", name, got)
+        }
+        return
+    }
+    if math.Abs(got-want) > 1e-6 {
+        t.Errorf("%s = %v, want %v", name, got, want)
+    }
+}
+
+func TestADXReference(t *testing.T) {
+    nan := math.NaN()
+    wantADX := []float64{nan, nan, nan, nan, nan, 71.8292682927, 50.8273553324, 51.166456953, 54.4566115219, 44.7013542462}
+    wantPlus := []float64{nan, nan, nan, 44.4444444444, 46.6666666667, 47.4358974359, 31.223628692, 48.9974937343, 44.0860215054, 33.5625409299}
+    wantMinus := []float64{nan, nan, nan, 11.1111111111, 6.6666666667, 5.1282051282, 26.1603375527, 15.5388471178, 10.6666666667, 20.055664702}
+
+    adx, plus, minus := ADXSeries(referenceKlines(), 3)
+    for i := range wantADX {
+        assertClose(t, "ADX", adx[i], wantADX[i])
+        assertClose(t, "+DI", plus[i], wantPlus[i])
+        assertClose(t, "-DI", minus[i], wantMinus[i])
+    }
+
+    last, lastPlus, lastMinus := CalculateADX(referenceKlines(), 3)
+    assertClose(t, "CalculateADX", last, wantADX[9])
+    assertClose(t, "CalculateADX +DI", lastPlus, wantPlus[9])
+    assertClose(t, "CalculateADX -DI", lastMinus, wantMinus[9])
+}
+
+func TestADXPureUptrend(t *testing.T) {
+    // +DM 1 and TR 1.5 every bar: +DI = 66.67, -DI = 0, DX and ADX = 100
+    adx, plus, minus := CalculateADX(linearKlines(40), 14)
+    assertClose(t, "ADX", adx, 100)
+    assertClose(t, "+DI", plus, 100.0/1.5)
+    assertClose(t, "-DI", minus, 0)
+}
+
+func TestSuperTrendReference(t *testing.T) {
+    nan := math.NaN()
+    wantValues := []float64{nan, nan, nan, 11.5, 10.9166666667, 11.7777777778, 12.2314814815, 11.9290123457, 12.4526748971, 13.1982167353}
+    wantBullish := []bool{false, false, false, false, true, true, false, true, true, false}
+
+    values, bullish := SuperTrendSeries(referenceKlines(), 3, 0.5)
+    for i := range wantValues {
+        assertClose(t, "SuperTrend", values[i], wantValues[i])
+        if bullish[i] != wantBullish[i] {
+            t.Errorf("SuperTrend bullish[%d] = %v, want %v", i, bullish[i], wantBullish[i])
+        }
+    }
+}
+
+func TestIchimokuLinear(t *testing.T) {
+    klines := linearKlines(80)
+    cloud := CalculateIchimoku(klines)
+    if !cloud.Valid {
+        t.Fatal("Ichimoku not valid with 80 klines")
+    }
+    // Last bar 79: Tenkan (80+71)/2, Kijun (80+54)/2. Spans from bar 53:
+    // A = ((54+45)/2 + (54+28)/2)/2, B = (54+2)/2
+    assertClose(t, "Tenkan", cloud.Tenkan, 75.5)
+    assertClose(t, "Kijun", cloud.Kijun, 67)
+    assertClose(t, "SpanA", cloud.SpanA, 45.25)
+    assertClose(t, "SpanB", cloud.SpanB, 28)
+    if !cloud.ChikouBullish || !cloud.AboveCloud(klines[79].Close) {
+        t.Error("rising market should be bullish and above the cloud")
+    }
+
+    if CalculateIchimoku(klines[:77]).Valid {
+        t.Error("Ichimoku valid with 77 klines, needs 78")
+    }
+    if !CalculateIchimoku(klines[:78]).Valid {
+        t.Error("Ichimoku not valid with 78 klines")
+    }
+}
+
+func TestOBVReference(t *testing.T) {
+    want := []float64{0, 150, 270, 140, 300, 470, 360, 540, 340, 250}
+    got := OBVSeries(referenceKlines())
+    for i := range want {
+        assertClose(t, "OBV", got[i], want[i])
+    }
+    assertClose(t, "CalculateOBV", CalculateOBV(referenceKlines()), 250)
+}
+
+func TestMFIReference(t *testing.T) {
+    assertClose(t, "MFI(3)", CalculateMFI(referenceKlines(), 3), 81.6185351927089)
+    assertClose(t, "MFI rising", CalculateMFI(linearKlines(20), 14), 100)
+}
+
+func TestKeltnerReference(t *testing.T) {
+    upper, middle, lower := CalculateKeltnerChannels(referenceKlines(), 3, 2)
+    assertClose(t, "Keltner upper", upper, 15.463541666666666)
+    assertClose(t, "Keltner middle", middle, 12.463541666666666)
+    assertClose(t, "Keltner lower", lower, 9.463541666666666)
+}
+
+func TestCCIReference(t *testing.T) {
+    assertClose(t, "CCI(5)", CalculateCCI(referenceKlines(), 5), -14.314928425357655)
+
+    // Typical prices 1..5: mean 3, mean deviation 1.2 -> (5-3)/(0.015*1.2)
+    klines := make([]types.Kline, 5)
+    for i := range klines {
+        tp := float64(i + 1)
+        klines[i] = types.Kline{High: tp, Low: tp, Close: tp}
+    }
+    assertClose(t, "CCI ramp", CalculateCCI(klines, 5), 2/(0.015*1.2))
+}
+
+func TestWilliamsRReference(t *testing.T) {
+    assertClose(t, "Williams %R(5)", CalculateWilliamsR(referenceKlines(), 5), -60)
+}
+
+func TestParabolicSARReference(t *testing.T) {
+    nan := math.NaN()
+    want := []float64{nan, 9, 9, 9.18, 9.3492, 9.601264, 9.9411376, 10.24702384, 10.6373809792, 11.1081476421}
+    values, bullish := ParabolicSARSeries(referenceKlines(), 0.02, 0.2)
+    for i := range want {
+        assertClose(t, "PSAR", values[i], want[i])
+        if i > 0 && !bullish[i] {
+            t.Errorf("PSAR bullish[%d] = false, want true", i)
+        }
+    }
+}
+
+func TestAdvancedIndicatorsShortInput(t *testing.T) {
+    for n := 0; n <= 3; n++ {
+        klines := referenceKlines()[:n]
+
+        if adx, plus, minus := CalculateADX(klines, 14); adx != 0 || plus != 0 || minus != 0 {
+            t.Errorf("ADX with %d klines = %v %v %v, want 0", n, adx, plus, minus)
+        }
+        if v, _ := CalculateSuperTrend(klines, 10, 3); v != 0 {
+            t.Errorf("SuperTrend with %d klines = %v, want 0", n, v)
+        }
+        if CalculateIchimoku(klines).Valid {
+            t.Errorf("Ichimoku valid with %d klines", n)
+        }
+        if obv := OBVSeries(klines); len(obv) != n {
+            t.Errorf("OBV series length %d for %d klines", len(obv), n)
+        }
+        if mfi := CalculateMFI(klines, 14); mfi != 50 {
+            t.Errorf("MFI with %d klines = %v, want 50", n, mfi)
+        }
+        if u, m, l := CalculateKeltnerChannels(klines, 20, 2); u != 0 || m != 0 || l != 0 {
+            t.Errorf("Keltner with %d klines not zero", n)
+        }
+        if cci := CalculateCCI(klines, 20); cci != 0 {
+            t.Errorf("CCI with %d klines = %v, want 0", n, cci)
+        }
+        if wr := CalculateWilliamsR(klines, 14); wr != -50 {
+            t.Errorf("Williams %%R with %d klines = %v, want -50", n, wr)
+        }
+        if n < 2 {
+            if sar, _ := CalculateParabolicSAR(klines, 0.02, 0.2); sar != 0 {
+                t.Errorf("PSAR with %d klines = %v, want 0", n, sar)
+            }
+        }
+    }
+}
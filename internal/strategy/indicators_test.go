@@ -0,0 +1,507 @@
+// File: internal/strategy/indicators_test.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "math"
+    "testing"
+    "time"
+)
+
+// Reference batch algorithms as they were before the streaming indicators
+// (baseline indicators.go). The wrappers must reproduce them exactly.
+
+func refSMA(prices []float64, period int) float64 {
+    if len(prices) < period {
+        return 0
+    }
+    sum := 0.0
+    for i := len(prices) - period; i < len(prices); i++ {
+        sum += prices[i]
+    }
+    return sum / float64(period)
+}
+
+func refRSI(prices []float64, period int) float64 {
+    if len(prices) < period+1 {
+        return 50.0
+    }
+    gains := make([]float64, 0)
+    losses := make([]float64, 0)
+    for i := 1; i < len(prices); i++ {
+        change := prices[i] - prices[i-1]
+        if change > 0 {
+            gains = append(gains, change)
+            losses = append(losses, 0)
+        } else {
+            gains = append(gains, 0)
+            losses = append(losses, math.Abs(change))
+        }
+    }
+    if len(gains) < period {
+        return 50.0
+    }
+    avgGain, avgLoss := 0.0, 0.0
+    for i := 0; i < period; i++ {
+        avgGain += gains[i]
+        avgLoss += losses[i]
+    }
+    avgGain /= float64(period)
+    avgLoss /= float64(period)
+    for i := period; i < len(gains); i++ {
+        avgGain = (avgGain*float64(period-1) + gains[i]) / float64(period)
+        avgLoss = (avgLoss*float64(period-1) + losses[i]) / float64(period)
+    }
+    if avgLoss == 0 {
+        if avgGain == 0 {
+            return 50.0
+        }
+        return 100.0
+    }
+    rsi := 100.0 - (100.0 / (1.0 + avgGain/avgLoss))
+    return math.Max(0, math.Min(100, rsi))
+}
+
+func refEMA(prices []float64, period int) float64 {
+    if len(prices) < period {
+        return 0
+    }
+    multiplier := 2.0 / float64(period+1)
+    ema := refSMA(prices[:period], period)
+    for i := period; i < len(prices); i++ {
+        ema = (prices[i]-ema)*multiplier + ema
+    }
+    return ema
+}
+
+func refMACD(prices []float64) (macd, signal, histogram float64) {
+    if len(prices) < 26 {
+        return 0, 0, 0
+    }
+    macd = refEMA(prices, 12) - refEMA(prices, 26)
+    macdValues := make([]float64, 0)
+    for i := 26; i < len(prices); i++ {
+        macdValues = append(macdValues, refEMA(prices[:i+1], 12)-refEMA(prices[:i+1], 26))
+    }
+    if len(macdValues) >= 9 {
+        signal = refEMA(macdValues, 9)
+        histogram = macd - signal
+    }
+    return macd, signal, histogram
+}
+
+func refBollinger(prices []float64, period int, stdDev float64) (upper, middle, lower float64) {
+    if len(prices) < period {
+        return 0, 0, 0
+    }
+    middle = refSMA(prices, period)
+    variance := 0.0
+    for i := len(prices) - period; i < len(prices); i++ {
+        variance += math.Pow(prices[i]-middle, 2)
+    }
+    deviation := math.Sqrt(variance / float64(period))
+    return middle + stdDev*deviation, middle, middle - stdDev*deviation
+}
+
+func refATR(klines []types.Kline, period int) float64 {
+    if len(klines) < period+1 {
+        return 0
+    }
+    trueRanges := make([]float64, 0)
+    for i := 1; i < len(klines); i++ {
+        highLow := klines[i].High - klines[i].Low
+        highClose := math.Abs(klines[i].High - klines[i-1].Close)
+        lowClose := math.Abs(klines[i].Low - klines[i-1].Close)
+        trueRanges = append(trueRanges, math.Max(highLow, math.Max(highClose, lowClose)))
+    }
+    return refSMA(trueRanges, period)
+}
+
+func refStochastic(klines []types.Kline, period int) (k, d float64) {
+    if len(klines) < period {
+        return 50, 50
+    }
+    recent := klines[len(klines)-period:]
+    high, low := recent[0].High, recent[0].Low
+    for _, kline := range recent {
+        high = math.Max(high, kline.High)
+        low = math.Min(low, kline.Low)
+    }
+    if high-low == 0 {
+        return 50, 50
+    }
+    k = (klines[len(klines)-1].Close - low) / (high - low) * 100
+    return k, k
+}
+
+// Fixtures
+
+// wavyKlines is a deterministic trending, oscillating series
+func wavyKlines(n int) []types.Kline {
+    klines := make([]types.Kline, n)
+    start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    price := 100.0
+    for i := range klines {
+        open := price
+        price = 100 + float64(i)*0.15 + 6*math.Sin(float64(i)/4) + 2*math.Cos(float64(i)*1.7)
+        klines[i] = types.Kline{
+            OpenTime:  start.Add(time.Duration(i) * time.Minute),
+            CloseTime: start.Add(time.Duration(i+1)*time.Minute - time.Millisecond),
+            Open:      open,
+            High:      math.Max(open, price) + 0.4 + 0.3*math.Abs(math.Sin(float64(i))),
+            Low:       math.Min(open, price) - 0.4 - 0.3*math.Abs(math.Cos(float64(i))),
+            Close:     price,
+            Volume:    1000 + 300*math.Sin(float64(i)/3),
+        }
+    }
+    return klines
+}
+
+// flatKlines never moves
+func flatKlines(n int) []types.Kline {
+    klines := make([]types.Kline, n)
+    for i := range klines {
+        klines[i] = types.Kline{Open: 50, High: 50, Low: 50, Close: 50, Volume: 10}
+    }
+    return klines
+}
+
+// risingKlines only goes up
+func risingKlines(n int) []types.Kline {
+    klines := make([]types.Kline, n)
+    for i := range klines {
+        price := 10 + float64(i)
+        klines[i] = types.Kline{Open: price - 0.5, High: price + 0.2, Low: price - 0.7, Close: price, Volume: 5}
+    }
+    return klines
+}
+
+func closesOf(klines []types.Kline) []float64 {
+    closes := make([]float64, len(klines))
+    for i, k := range klines {
+        closes[i] = k.Close
+    }
+    return closes
+}
+
+var indicatorFixtures = []struct {
+    name   string
+    klines []types.Kline
+}{
+    {"wavy", wavyKlines(120)},
+    {"flat", flatKlines(60)},
+    {"rising", risingKlines(60)},
+}
+
+// fixtureLengths covers the readiness boundaries: RSI(14) at 15 prices, MACD
+// at 26, its signal line from the 27th price with a value at 35
+var fixtureLengths = []int{0, 1, 2, 13, 14, 15, 16, 19, 20, 21, 25, 26, 27, 34, 35, 36, 60}
+
+func TestBatchWrappersMatchBaseline(t *testing.T) {
+    for _, fx := range indicatorFixtures {
+        for _, n := range fixtureLengths {
+            if n > len(fx.klines) {
+                continue
+            }
+            klines := fx.klines[:n]
+            closes := closesOf(klines)
+
+            checks := []struct {
+                name      string
+                got, want []float64
+            }{
+                {"RSI(14)", []float64{CalculateRSI(closes, 14)}, []float64{refRSI(closes, 14)}},
+                {"RSI(6)", []float64{CalculateRSI(closes, 6)}, []float64{refRSI(closes, 6)}},
+                {"EMA(12)", []float64{CalculateEMA(closes, 12)}, []float64{refEMA(closes, 12)}},
+                {"EMA(26)", []float64{CalculateEMA(closes, 26)}, []float64{refEMA(closes, 26)}},
+                {"ATR(14)", []float64{CalculateATR(klines, 14)}, []float64{refATR(klines, 14)}},
+            }
+            macd, signal, hist := CalculateMACD(closes)
+            refMacd, refSignal, refHist := refMACD(closes)
+            checks = append(checks, struct {
+                name      string
+                got, want []float64
+            }{"MACD", []float64{macd, signal, hist}, []float64{refMacd, refSignal, refHist}})
+            upper, middle, lower := CalculateBollingerBands(closes, 20, 2.0)
+            refUpper, refMiddle, refLower := refBollinger(closes, 20, 2.0)
+            checks = append(checks, struct {
+                name      string
+                got, want []float64
+            }{"Bollinger(20,2)", []float64{upper, middle, lower}, []float64{refUpper, refMiddle, refLower}})
+            k, d := CalculateStochastic(klines, 14)
+            refK, refD := refStochastic(klines, 14)
+            checks = append(checks, struct {
+                name      string
+                got, want []float64
+            }{"Stochastic(14)", []float64{k, d}, []float64{refK, refD}})
+
+            for _, c := range checks {
+                for i := range c.got {
+                    if !sameFloat(c.got[i], c.want[i]) {
+                        t.Errorf("%s/%d bars: %s[%d] = %v, baseline %v", fx.name, n, c.name, i, c.got[i], c.want[i])
+                    }
+                }
+            }
+        }
+    }
+}
+
+// sameFloat allows for the last-bit rounding differences of summing in a
+// different order; values from the same operations compare exactly
+func sameFloat(a, b float64) bool {
+    return a == b || math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+}
+
+func TestReadinessBoundaries(t *testing.T) {
+    closes := closesOf(wavyKlines(40))
+
+    rsi := NewRSI(14)
+    for i, p := range closes[:16] {
+        rsi.Update(p)
+        if want := i+1 >= 15; rsi.Ready() != want {
+            t.Errorf("RSI(14) after %d prices: Ready() = %v, want %v", i+1, rsi.Ready(), want)
+        }
+    }
+
+    m := NewMACD()
+    for i, p := range closes[:36] {
+        macd, signal, _ := m.Update(p)
+        n := i + 1
+        if m.Ready() != (n >= 26) {
+            t.Errorf("MACD after %d prices: Ready() = %v", n, m.Ready())
+        }
+        if n < 26 && macd != 0 {
+            t.Errorf("MACD after %d prices = %v, want 0 before ready", n, macd)
+        }
+        // Signal EMA(9) is fed from the 27th price, so it has a value at 35
+        if (signal != 0) != (n >= 35) {
+            t.Errorf("MACD signal after %d prices = %v", n, signal)
+        }
+    }
+}
+
+func TestSnapshotRestoreRoundTrip(t *testing.T) {
+    klines := wavyKlines(100)
+    closes := closesOf(klines)
+    half := 47
+
+    rsi, ema, macd := NewRSI(14), NewEMA(20), NewMACD()
+    bb, atr, stoch, vwap := NewBollinger(20, 2), NewATR(14), NewStochastic(14), NewVWAP()
+    for i := 0; i < half; i++ {
+        rsi.Update(closes[i])
+        ema.Update(closes[i])
+        macd.Update(closes[i])
+        bb.Update(closes[i])
+        atr.Update(klines[i])
+        stoch.Update(klines[i])
+        vwap.Update(klines[i])
+    }
+
+    rsi2, ema2, macd2 := NewRSI(14), NewEMA(20), NewMACD()
+    bb2, atr2, stoch2, vwap2 := NewBollinger(20, 2), NewATR(14), NewStochastic(14), NewVWAP()
+    rsi2.Restore(rsi.Snapshot())
+    ema2.Restore(ema.Snapshot())
+    macd2.Restore(macd.Snapshot())
+    bb2.Restore(bb.Snapshot())
+    atr2.Restore(atr.Snapshot())
+    stoch2.Restore(stoch.Snapshot())
+    vwap2.Restore(vwap.Snapshot())
+
+    // Both copies must continue identically and independently
+    for i := half; i < len(klines); i++ {
+        a, b := rsi.Update(closes[i]), rsi2.Update(closes[i])
+        if a != b {
+            t.Fatalf("RSI diverged at %d: %v vs %v", i, a, b)
+        }
+        if a, b := ema.Update(closes[i]), ema2.Update(closes[i]); a != b {
+            t.Fatalf("EMA diverged at %d: %v vs %v", i, a, b)
+        }
+        m1, s1, h1 := macd.Update(closes[i])
+        m2, s2, h2 := macd2.Update(closes[i])
+        if m1 != m2 || s1 != s2 || h1 != h2 {
+            t.Fatalf("MACD diverged at %d", i)
+        }
+        u1, _, l1 := bb.Update(closes[i])
+        u2, _, l2 := bb2.Update(closes[i])
+        if u1 != u2 || l1 != l2 {
+            t.Fatalf("Bollinger diverged at %d", i)
+        }
+        if a, b := atr.Update(klines[i]), atr2.Update(klines[i]); a != b {
+            t.Fatalf("ATR diverged at %d: %v vs %v", i, a, b)
+        }
+        k1, _ := stoch.Update(klines[i])
+        k2, _ := stoch2.Update(klines[i])
+        if k1 != k2 {
+            t.Fatalf("Stochastic diverged at %d", i)
+        }
+        if a, b := vwap.Update(klines[i]), vwap2.Update(klines[i]); a != b {
+            t.Fatalf("VWAP diverged at %d: %v vs %v", i, a, b)
+        }
+    }
+
+    // And end where the batch wrappers end
+    if got, want := rsi2.Value(), CalculateRSI(closes, 14); got != want {
+        t.Errorf("restored RSI = %v, batch %v", got, want)
+    }
+    if got, want := atr2.Value(), CalculateATR(klines, 14); !sameFloat(got, want) {
+        t.Errorf("restored ATR = %v, batch %v", got, want)
+    }
+}
+
+func TestSnapshotIsolatedFromLaterUpdates(t *testing.T) {
+    closes := closesOf(wavyKlines(60))
+    bb := NewBollinger(20, 2)
+    for _, p := range closes[:30] {
+        bb.Update(p)
+    }
+    snapshot := bb.Snapshot()
+    u, m, l := bb.Value()
+
+    for _, p := range closes[30:] {
+        bb.Update(p)
+    }
+
+    restored := NewBollinger(20, 2)
+    restored.Restore(snapshot)
+    if u2, m2, l2 := restored.Value(); u2 != u || m2 != m || l2 != l {
+        t.Errorf("snapshot changed by later updates: %v %v %v, want %v %v %v", u2, m2, l2, u, m, l)
+    }
+}
+
+// refDetectTrend is the baseline DetectTrend, which computed every indicator
+// again from the closes
+func refDetectTrend(klines []types.Kline) (string, float64) {
+    if len(klines) < 20 {
+        return "NEUTRAL", 0.5
+    }
+
+    closes := make([]float64, len(klines))
+    volumes := make([]float64, len(klines))
+
+    for i, k := range klines {
+        closes[i] = k.Close
+        volumes[i] = k.Volume
+    }
+
+    sma20 := refSMA(closes, 20)
+    sma50 := refSMA(closes, 50)
+    currentPrice := closes[len(closes)-1]
+    rsi := refRSI(closes, 14)
+    macd, signal, _ := refMACD(closes)
+
+    // Bollinger Bands
+    upperBB, _, lowerBB := refBollinger(closes, 20, 2.0)
+
+    // Volume analysis
+    currentVolume := volumes[len(volumes)-1]
+    volumeSpike, volumeRatio := DetectVolumeSpike(volumes[:len(volumes)-1], currentVolume)
+
+    bullishSignals := 0
+    bearishSignals := 0
+    totalSignals := 0
+
+    // Price vs SMAs (weight: 2)
+    if currentPrice > sma20 {
+        bullishSignals += 2
+    } else {
+        bearishSignals += 2
+    }
+    totalSignals += 2
+
+    if len(closes) >= 50 {
+        if sma20 > sma50 {
+            bullishSignals += 2
+        } else {
+            bearishSignals += 2
+        }
+        totalSignals += 2
+    }
+
+    // RSI (weight: 1)
+    if rsi > 50 && rsi < 70 {
+        bullishSignals++
+    } else if rsi < 50 && rsi > 30 {
+        bearishSignals++
+    }
+    totalSignals++
+
+    // MACD (weight: 2)
+    if macd > signal {
+        bullishSignals += 2
+    } else {
+        bearishSignals += 2
+    }
+    totalSignals += 2
+
+    // Bollinger Bands (weight: 1)
+    if currentPrice > (upperBB+lowerBB)/2 {
+        bullishSignals++
+    } else {
+        bearishSignals++
+    }
+    totalSignals++
+
+    // Volume confirmation (weight: 1)
+    if volumeSpike && volumeRatio > 2.0 {
+        if currentPrice > closes[len(closes)-2] {
+            bullishSignals++
+        } else {
+            bearishSignals++
+        }
+        totalSignals++
+    }
+
+    strength := float64(bullishSignals) / float64(totalSignals)
+
+    if strength > 0.65 {
+        return "BULLISH", strength
+    } else if strength < 0.35 {
+        return "BEARISH", 1 - strength
+    }
+
+    return "NEUTRAL", 0.5
+}
+
+
+func TestTrendIndicatorsMatchBatchWrappers(t *testing.T) {
+    for _, fx := range indicatorFixtures {
+        for _, n := range append(fixtureLengths, 50, 100, 120) {
+            if n > len(fx.klines) {
+                continue
+            }
+            klines := fx.klines[:n]
+            closes := closesOf(klines)
+            ind := ComputeTrendIndicators(klines)
+
+            macd, signal, histogram := CalculateMACD(closes)
+            upper, middle, lower := CalculateBollingerBands(closes, 20, 2.0)
+            checks := []struct {
+                name      string
+                got, want float64
+            }{
+                {"SMA20", ind.SMA20, CalculateSMA(closes, 20)},
+                {"SMA50", ind.SMA50, CalculateSMA(closes, 50)},
+                {"RSI", ind.RSI, CalculateRSI(closes, 14)},
+                {"MACD", ind.MACD, macd},
+                {"MACD signal", ind.MACDSignal, signal},
+                {"MACD histogram", ind.MACDHistogram, histogram},
+                {"BB upper", ind.UpperBB, upper},
+                {"BB middle", ind.MiddleBB, middle},
+                {"BB lower", ind.LowerBB, lower},
+            }
+            for _, c := range checks {
+                if !sameFloat(c.got, c.want) {
+                    t.Errorf("%s/%d %s = %v, want %v", fx.name, n, c.name, c.got, c.want)
+                }
+            }
+
+            trend, strength := DetectTrendFrom(ind)
+            wantTrend, wantStrength := refDetectTrend(klines)
+            if trend != wantTrend || strength != wantStrength {
+                t.Errorf("%s/%d DetectTrendFrom = %s %.3f, baseline DetectTrend = %s %.3f",
+                    fx.name, n, trend, strength, wantTrend, wantStrength)
+            }
+        }
+    }
+}
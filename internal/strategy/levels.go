@@ -0,0 +1,144 @@
+// File: internal/strategy/levels.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "math"
+    "sort"
+)
+
+// LevelConfig controls pivot detection and clustering for support/resistance
+type LevelConfig struct {
+    PivotBars       int     // Bars on each side of a pivot high/low
+    ClusterATR      float64 // Pivots closer than this many ATRs are merged
+    ClusterPercent  float64 // Fallback merge distance (% of price) when ATR is unavailable
+    RecencyHalfLife float64 // Bars after which a touch counts half
+}
+
+// DefaultLevelConfig returns the settings used by the strategy
+func DefaultLevelConfig() LevelConfig {
+    return LevelConfig{
+        PivotBars:       3,
+        ClusterATR:      0.5,
+        ClusterPercent:  0.5,
+        RecencyHalfLife: 50,
+    }
+}
+
+type pivot struct {
+    price float64
+    index int
+}
+
+type levelCluster struct {
+    pivots []pivot
+    sum    float64
+}
+
+func (c *levelCluster) mean() float64 {
+    return c.sum / float64(len(c.pivots))
+}
+
+// CalculateSupportResistance finds pivot highs and lows, clusters pivots that
+// sit within a fraction of ATR of each other and returns the resulting levels
+// split around the latest close. Both slices are ranked by score (touch count
+// weighted by recency), strongest first.
+func CalculateSupportResistance(klines []types.Kline) (supports, resistances []types.PriceLevel) {
+    return CalculatePriceLevels(klines, DefaultLevelConfig())
+}
+
+// CalculatePriceLevels is CalculateSupportResistance with explicit settings
+func CalculatePriceLevels(klines []types.Kline, cfg LevelConfig) (supports, resistances []types.PriceLevel) {
+    if len(klines) < 2*cfg.PivotBars+1 || len(klines) < 20 {
+        return nil, nil
+    }
+
+    highs := make([]float64, len(klines))
+    lows := make([]float64, len(klines))
+    for i, k := range klines {
+        highs[i] = k.High
+        lows[i] = k.Low
+    }
+
+    pivots := []pivot{}
+    for _, i := range FindSwingHighs(highs, cfg.PivotBars) {
+        pivots = append(pivots, pivot{highs[i], i})
+    }
+    for _, i := range FindSwingLows(lows, cfg.PivotBars) {
+        pivots = append(pivots, pivot{lows[i], i})
+    }
+    if len(pivots) == 0 {
+        return nil, nil
+    }
+
+    current := klines[len(klines)-1].Close
+    tolerance := CalculateATR(klines, 14) * cfg.ClusterATR
+    if tolerance <= 0 {
+        tolerance = current * cfg.ClusterPercent / 100
+    }
+
+    // Single pass over pivots sorted by price; a pivot joins the current
+    // cluster while it stays within tolerance of the cluster's mean
+    sort.Slice(pivots, func(i, j int) bool { return pivots[i].price < pivots[j].price })
+    clusters := []*levelCluster{}
+    for _, p := range pivots {
+        if n := len(clusters); n > 0 && p.price-clusters[n-1].mean() <= tolerance {
+            clusters[n-1].pivots = append(clusters[n-1].pivots, p)
+            clusters[n-1].sum += p.price
+            continue
+        }
+        clusters = append(clusters, &levelCluster{pivots: []pivot{p}, sum: p.price})
+    }
+
+    last := len(klines) - 1
+    for _, c := range clusters {
+        level := types.PriceLevel{Price: c.mean(), Touches: len(c.pivots), BarsAgo: last}
+        for _, p := range c.pivots {
+            if last-p.index < level.BarsAgo {
+                level.BarsAgo = last - p.index
+                level.LastTouch = klines[p.index].OpenTime
+            }
+            level.Score += math.Pow(0.5, float64(last-p.index)/cfg.RecencyHalfLife)
+        }
+
+        if level.Price <= current {
+            level.Kind = "SUPPORT"
+            supports = append(supports, level)
+        } else {
+            level.Kind = "RESISTANCE"
+            resistances = append(resistances, level)
+        }
+    }
+
+    rank := func(levels []types.PriceLevel) {
+        sort.SliceStable(levels, func(i, j int) bool { return levels[i].Score > levels[j].Score })
+    }
+    rank(supports)
+    rank(resistances)
+
+    return supports, resistances
+}
+
+// NearestLevels returns the closest support below and resistance above price
+// among levels with at least minTouches. Missing levels have Price 0.
+func NearestLevels(levels []types.PriceLevel, price float64, minTouches int) (support, resistance types.PriceLevel) {
+    for _, l := range levels {
+        if l.Touches < minTouches {
+            continue
+        }
+        if l.Price <= price && (support.Price == 0 || l.Price > support.Price) {
+            support = l
+        }
+        if l.Price > price && (resistance.Price == 0 || l.Price < resistance.Price) {
+            resistance = l
+        }
+    }
+    return support, resistance
+}
+
+// DescribeLevel formats a level for logs
+func DescribeLevel(l types.PriceLevel) string {
+    return fmt.Sprintf("%s $%.4f (%d touches, %d bars ago)", l.Kind, l.Price, l.Touches, l.BarsAgo)
+}
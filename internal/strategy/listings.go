@@ -0,0 +1,297 @@
+// File: internal/strategy/listings.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "log"
+    "math"
+    "sort"
+    "strings"
+    "time"
+)
+
+// Listing is a symbol that started trading while the bot was running
+type Listing struct {
+    Symbol     string
+    DetectedAt time.Time
+    Source     string // "ticker" or "exchangeInfo"
+    Ticker     types.Ticker
+}
+
+// listingTracker remembers the symbols seen trading so new ones stand out.
+// The first cycle only seeds it.
+type listingTracker struct {
+    seeded  bool
+    known   map[string]bool     // Symbols seen with a price or as TRADING
+    trading map[string]bool     // TRADING symbols of the last exchangeInfo
+    tracked map[string]*Listing // Listings still on the listing analysis path
+}
+
+func newListingTracker() *listingTracker {
+    return &listingTracker{
+        known:   map[string]bool{},
+        tracked: map[string]*Listing{},
+    }
+}
+
+// DetectNewListings diffs the ticker set and the exchangeInfo TRADING set
+// against earlier cycles and returns the listings that are new this cycle.
+// Only symbols inside the universe count. Call it after FindHotCoins so the
+// universe has current notional rates.
+func (s *MomentumStrategy) DetectNewListings(tickers []types.Ticker) []Listing {
+    t := s.listings
+    now := time.Now()
+
+    trackFor := time.Duration(s.config.Strategy.NewListings.TrackMinutes) * time.Minute
+    if trackFor <= 0 {
+        trackFor = 4 * time.Hour
+    }
+    for symbol, l := range t.tracked {
+        if now.Sub(l.DetectedAt) > trackFor {
+            log.Printf("🆕 %s leaves the new-listing path after %.0f minutes", symbol, now.Sub(l.DetectedAt).Minutes())
+            delete(t.tracked, symbol)
+        }
+    }
+
+    bySymbol := make(map[string]types.Ticker, len(tickers))
+    unknown := false
+    for _, ticker := range tickers {
+        bySymbol[ticker.Symbol] = ticker
+        if ticker.LastPrice > 0 && !t.known[ticker.Symbol] && t.seeded {
+            unknown = true
+        }
+    }
+
+    // A ticker for a symbol we never saw: exchangeInfo is stale, reload it
+    if unknown {
+        s.universe.Refresh()
+    }
+    trading := s.universe.TradingSymbols()
+
+    candidates := map[string]string{}
+    for symbol, ticker := range bySymbol {
+        if ticker.LastPrice > 0 && !t.known[symbol] {
+            candidates[symbol] = "ticker"
+        }
+    }
+    if t.trading != nil {
+        for symbol := range trading {
+            if !t.trading[symbol] && !t.known[symbol] {
+                candidates[symbol] = "exchangeInfo"
+            }
+        }
+    }
+    if trading != nil {
+        t.trading = trading
+    }
+
+    var listings []Listing
+    for symbol, source := range candidates {
+        ticker, ok := bySymbol[symbol]
+        if !ok || ticker.LastPrice <= 0 {
+            continue // Not trading yet - picked up once it has a price
+        }
+        if !t.seeded {
+            t.known[symbol] = true
+            continue
+        }
+        if trading != nil && !trading[symbol] {
+            continue // exchangeInfo does not list it as TRADING yet - check again next cycle
+        }
+        t.known[symbol] = true
+
+        ticker, ok = s.universe.Include(ticker)
+        if !ok {
+            continue
+        }
+
+        listing := Listing{Symbol: symbol, DetectedAt: now, Source: source, Ticker: ticker}
+        t.tracked[symbol] = &listing
+        listings = append(listings, listing)
+        log.Printf("🆕 NEW LISTING: %s at %.8g (%s, volume %.0f %s)",
+            symbol, ticker.LastPrice, source, ticker.QuoteVolume, ticker.QuoteAsset)
+    }
+
+    if !t.seeded {
+        t.seeded = true
+        log.Printf("🆕 Listing detector seeded with %d symbols", len(t.known))
+    }
+
+    sort.Slice(listings, func(i, j int) bool { return listings[i].Symbol < listings[j].Symbol })
+    return listings
+}
+
+// ListingCandidates returns the current tickers of tracked listings that are
+// not already among the given coins
+func (s *MomentumStrategy) ListingCandidates(tickers, exclude []types.Ticker) []types.Ticker {
+    skip := make(map[string]bool, len(exclude))
+    for _, t := range exclude {
+        skip[t.Symbol] = true
+    }
+
+    candidates := []types.Ticker{}
+    for _, ticker := range tickers {
+        if _, tracked := s.listings.tracked[ticker.Symbol]; !tracked || skip[ticker.Symbol] {
+            continue
+        }
+        if ticker, ok := s.universe.Include(ticker); ok {
+            candidates = append(candidates, ticker)
+        }
+    }
+    return candidates
+}
+
+// IsNewListing reports whether a symbol is on the new-listing analysis path
+func (s *MomentumStrategy) IsNewListing(symbol string) bool {
+    _, tracked := s.listings.tracked[symbol]
+    return tracked
+}
+
+// AnalyzeNewListing scores a fresh listing from the bars it has traded so
+// far: gain since the first trade, pullback from the high, VWAP since
+// listing and short-term momentum
+func (s *MomentumStrategy) AnalyzeNewListing(ticker types.Ticker) types.Signal {
+    cfg := s.config.Strategy.NewListings
+    signal := types.Signal{
+        Symbol:       ticker.Symbol,
+        Action:       "HOLD",
+        Price:        ticker.LastPrice,
+        Timestamp:    ticker.Timestamp,
+        MTFScore:     0.5,
+        Regime:       "NEW_LISTING",
+        Strategy:     "new_listing",
+        QuoteAsset:   ticker.QuoteAsset,
+        NotionalRate: ticker.NotionalRate,
+    }
+
+    timeframe := cfg.Timeframe
+    if timeframe == "" {
+        timeframe = "1m"
+    }
+    minBars := cfg.MinBars
+    if minBars <= 0 {
+        minBars = 5
+    }
+    momentumBars := cfg.MomentumBars
+    if momentumBars <= 0 {
+        momentumBars = 5
+    }
+    minStrength := cfg.MinStrength
+    if minStrength <= 0 {
+        minStrength = 0.75
+    }
+
+    klines, err := s.client.GetKlines(ticker.Symbol, timeframe, 500)
+    if err != nil || len(klines) == 0 {
+        signal.Reason = fmt.Sprintf("New listing: failed to get %s klines: %v", timeframe, err)
+        return signal
+    }
+
+    price := ticker.LastPrice
+    firstPrice := klines[0].Open
+    high := 0.0
+    for _, k := range klines {
+        high = math.Max(high, k.High)
+    }
+    change := (price - firstPrice) / firstPrice * 100
+    pullback := (high - price) / high * 100
+    vwap := CalculateVWAP(klines)
+    momentum := 0.0
+    if len(klines) > momentumBars {
+        momentum = (price - klines[len(klines)-1-momentumBars].Close) / klines[len(klines)-1-momentumBars].Close * 100
+    }
+
+    vwapType := RuleScored
+    if cfg.RequireAboveVWAP {
+        vwapType = RuleRequired
+    }
+
+    explanation := &types.SignalExplanation{
+        Threshold:        minStrength,
+        Regime:           "NEW_LISTING",
+        RegimeConfidence: 1,
+        Indicators: map[string]float64{
+            "first_price": firstPrice,
+            "high":        high,
+            "vwap":        vwap,
+            "bars":        float64(len(klines)),
+        },
+    }
+    add := func(name, ruleType string, weight, value, threshold float64, passed bool, detail string) {
+        points := 0.0
+        if ruleType == RuleScored {
+            explanation.MaxScore += weight
+            if passed {
+                points = weight
+                explanation.Score += weight
+            }
+        }
+        explanation.Criteria = append(explanation.Criteria, types.SignalCriterion{
+            Name: name, Criterion: name, Type: ruleType, Value: value, Threshold: threshold,
+            Passed: passed, Weight: weight, Points: points, Detail: detail,
+        })
+    }
+
+    add("listing_bars", RuleRequired, 0, float64(len(klines)), float64(minBars), len(klines) >= minBars,
+        fmt.Sprintf("%d %s bars traded", len(klines), timeframe))
+    add("listing_volume", RuleRequired, 0, ticker.NotionalVolume, cfg.MinQuoteVolume, ticker.NotionalVolume >= cfg.MinQuoteVolume,
+        fmt.Sprintf("$%.0f volume", ticker.NotionalVolume))
+    add("listing_change", RuleScored, 30, change, cfg.MinChangePercent, change >= cfg.MinChangePercent,
+        fmt.Sprintf("%+.1f%% since first trade", change))
+    if cfg.MaxPullbackPercent > 0 {
+        add("listing_pullback", RuleScored, 25, pullback, cfg.MaxPullbackPercent, pullback <= cfg.MaxPullbackPercent,
+            fmt.Sprintf("%.1f%% below the high", pullback))
+    }
+    if price > vwap {
+        add("listing_vwap", vwapType, 20, price, vwap, true, fmt.Sprintf("above VWAP %.8g", vwap))
+    } else {
+        add("listing_vwap", vwapType, 20, price, vwap, false, fmt.Sprintf("below VWAP %.8g", vwap))
+    }
+    add("listing_momentum", RuleScored, 25, momentum, 0, momentum > 0,
+        fmt.Sprintf("%+.1f%% over %d bars", momentum, momentumBars))
+
+    // Wide stops for new listings - ATR over what history there is
+    atrPeriod := 14
+    if len(klines)-1 < atrPeriod {
+        atrPeriod = len(klines) - 1
+    }
+    if atrPeriod > 0 {
+        signal.ATR = CalculateATR(klines, atrPeriod)
+    }
+
+    if explanation.MaxScore > 0 {
+        explanation.Strength = explanation.Score / explanation.MaxScore
+    }
+    signal.Strength = explanation.Strength
+    signal.Explanation = explanation
+
+    failed := []string{}
+    for _, c := range explanation.Criteria {
+        if c.Type == RuleRequired && !c.Passed {
+            failed = append(failed, c.Detail)
+        }
+    }
+    explanation.Notes = append(explanation.Notes,
+        fmt.Sprintf("New listing, first trade %s ago", time.Since(klines[0].OpenTime).Round(time.Minute)))
+
+    summary := fmt.Sprintf("New listing: %+.1f%% since first trade, %.1f%% below high, %d bars",
+        change, pullback, len(klines))
+    switch {
+    case len(failed) > 0:
+        explanation.Rejected = true
+        explanation.RejectReason = strings.Join(failed, ", ")
+        signal.Reason = fmt.Sprintf("Rejected: %s | %s", explanation.RejectReason, summary)
+    case signal.Strength >= minStrength:
+        signal.Action = "BUY"
+        signal.Reason = fmt.Sprintf("Score: %.0f%% | %s | %s", signal.Strength*100, strings.Join(PassedCriteria(explanation), ", "), summary)
+    default:
+        signal.Reason = fmt.Sprintf("Score too low (%.0f%% < %.0f%%): %s", signal.Strength*100, minStrength*100, summary)
+    }
+
+    for _, line := range ExplanationLines(explanation) {
+        log.Printf("   %s", line)
+    }
+    return signal
+}
@@ -0,0 +1,184 @@
+// File: internal/strategy/market.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "log"
+    "sort"
+    "strings"
+    "time"
+)
+
+// ComputeBreadth fills the breadth fields of a market state from the tickers
+// quoted in quoteAsset. Pass them through Universe.MarketPairs first so
+// stablecoins and leveraged tokens don't count.
+func ComputeBreadth(state *types.MarketState, tickers []types.Ticker, quoteAsset string) {
+    counted, aboveVWAP := 0, 0
+    for _, t := range tickers {
+        if !strings.HasSuffix(t.Symbol, quoteAsset) || t.LastPrice == 0 {
+            continue
+        }
+        counted++
+        state.QuoteVolume += t.QuoteVolume
+
+        if t.PriceChangePercent > 0 {
+            state.Advancers++
+        } else if t.PriceChangePercent < 0 {
+            state.Decliners++
+        }
+        if t.WeightedAvgPrice > 0 && t.LastPrice > t.WeightedAvgPrice {
+            aboveVWAP++
+        }
+
+        switch t.Symbol {
+        case "BTC" + quoteAsset:
+            state.BTCChange = t.PriceChangePercent
+        case "ETH" + quoteAsset:
+            state.ETHChange = t.PriceChangePercent
+        }
+    }
+
+    if state.Decliners > 0 {
+        state.AdvanceDecline = float64(state.Advancers) / float64(state.Decliners)
+    } else {
+        state.AdvanceDecline = float64(state.Advancers)
+    }
+    if counted > 0 {
+        state.AboveVWAP = float64(aboveVWAP) / float64(counted)
+    }
+}
+
+// UpdateMarketState computes the market state from the full ticker set and
+// BTC/ETH klines. Call it once per cycle before generating signals.
+func (s *MomentumStrategy) UpdateMarketState(tickers []types.Ticker) types.MarketState {
+    cfg := s.config.Strategy.MarketFilter
+    quote := cfg.QuoteAsset
+    if quote == "" {
+        quote = "USDT"
+    }
+    timeframe := cfg.TrendTimeframe
+    if timeframe == "" {
+        timeframe = "1h"
+    }
+
+    state := types.MarketState{Timestamp: time.Now(), BTCTrend: "NEUTRAL", ETHTrend: "NEUTRAL"}
+    pairs := s.universe.MarketPairs(tickers, quote)
+    ComputeBreadth(&state, pairs, quote)
+
+    if klines, err := s.client.GetKlines("BTC"+quote, timeframe, 100); err == nil && len(klines) > 0 {
+        state.BTCTrend, state.BTCStrength = DetectTrend(klines)
+    } else {
+        log.Printf("⚠️  Market state: failed to get BTC klines: %v", err)
+    }
+    if klines, err := s.client.GetKlines("ETH"+quote, timeframe, 100); err == nil && len(klines) > 0 {
+        state.ETHTrend, state.ETHStrength = DetectTrend(klines)
+    } else {
+        log.Printf("⚠️  Market state: failed to get ETH klines: %v", err)
+    }
+
+    if cfg.Enabled {
+        state.VolumeChange = s.volumeChange(pairs, quote)
+
+        if state.BTCTrend == "BEARISH" && state.BTCStrength >= cfg.BTCBearishStrength {
+            state.RiskOffReasons = append(state.RiskOffReasons,
+                fmt.Sprintf("BTC bearish (%.0f%%)", state.BTCStrength*100))
+        }
+        if cfg.MinAdvanceDecline > 0 && state.AdvanceDecline < cfg.MinAdvanceDecline {
+            state.RiskOffReasons = append(state.RiskOffReasons,
+                fmt.Sprintf("A/D %.2f < %.2f", state.AdvanceDecline, cfg.MinAdvanceDecline))
+        }
+        if cfg.MinAboveVWAP > 0 && state.AboveVWAP < cfg.MinAboveVWAP {
+            state.RiskOffReasons = append(state.RiskOffReasons,
+                fmt.Sprintf("%.0f%% above VWAP < %.0f%%", state.AboveVWAP*100, cfg.MinAboveVWAP*100))
+        }
+        state.RiskOff = len(state.RiskOffReasons) > 0
+    }
+
+    s.market = &state
+    return state
+}
+
+// volumeChange compares the quote volume of the last volume_lookback_minutes
+// with the window before it, summed over the most liquid pairs. 24h ticker
+// volumes barely move between cycles, so this reads closed 1m klines.
+func (s *MomentumStrategy) volumeChange(tickers []types.Ticker, quote string) float64 {
+    cfg := s.config.Strategy.MarketFilter
+    minutes := cfg.VolumeLookbackMinutes
+    if minutes <= 0 {
+        minutes = 60
+    }
+    if minutes > 499 {
+        minutes = 499 // Two windows plus the open bar in one request
+    }
+    count := cfg.VolumeSymbols
+    if count <= 0 {
+        count = 20
+    }
+
+    pairs := make([]types.Ticker, 0, len(tickers))
+    for _, t := range tickers {
+        if strings.HasSuffix(t.Symbol, quote) && t.QuoteVolume > 0 {
+            pairs = append(pairs, t)
+        }
+    }
+    sort.Slice(pairs, func(i, j int) bool { return pairs[i].QuoteVolume > pairs[j].QuoteVolume })
+    if len(pairs) > count {
+        pairs = pairs[:count]
+    }
+
+    recent, prior := 0.0, 0.0
+    for _, t := range pairs {
+        klines, err := s.client.GetKlines(t.Symbol, "1m", 2*minutes+1)
+        if err != nil {
+            log.Printf("⚠️  Market state: failed to get %s klines: %v", t.Symbol, err)
+            continue
+        }
+        r, p, ok := windowVolumes(klines, minutes, time.Now())
+        if ok {
+            recent += r
+            prior += p
+        }
+    }
+    if prior <= 0 {
+        return 0
+    }
+    return (recent - prior) / prior * 100
+}
+
+// windowVolumes sums the quote volume of the last bars closed klines and of the
+// bars before them. ok is false without enough history for both windows.
+func windowVolumes(klines []types.Kline, bars int, now time.Time) (recent, prior float64, ok bool) {
+    if n := len(klines); n > 0 && klines[n-1].CloseTime.After(now) {
+        klines = klines[:n-1] // Still open
+    }
+    if len(klines) < 2*bars {
+        return 0, 0, false
+    }
+    klines = klines[len(klines)-2*bars:]
+    for i, k := range klines {
+        if i < bars {
+            prior += k.QuoteVolume
+        } else {
+            recent += k.QuoteVolume
+        }
+    }
+    return recent, prior, true
+}
+
+// MarketState returns the state from the last UpdateMarketState call, or nil
+func (s *MomentumStrategy) MarketState() *types.MarketState {
+    return s.market
+}
+
+// DescribeMarketState formats the market state for logs and notifications
+func DescribeMarketState(m types.MarketState) string {
+    summary := fmt.Sprintf("BTC %s (%.0f%%, %+.2f%%) | ETH %s (%.0f%%, %+.2f%%) | A/D %d/%d (%.2f) | Above VWAP %.0f%% | Volume %+.1f%%",
+        m.BTCTrend, m.BTCStrength*100, m.BTCChange, m.ETHTrend, m.ETHStrength*100, m.ETHChange,
+        m.Advancers, m.Decliners, m.AdvanceDecline, m.AboveVWAP*100, m.VolumeChange)
+    if m.RiskOff {
+        summary += " | RISK-OFF: " + strings.Join(m.RiskOffReasons, ", ")
+    }
+    return summary
+}
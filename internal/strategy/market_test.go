@@ -0,0 +1,107 @@
+// File: internal/strategy/market_test.go
+// ============================================
+package strategy
+
+import (
+    "strings"
+    "testing"
+    "time"
+
+    "binance-trading-bot/pkg/types"
+)
+
+func minuteKlines(start time.Time, volumes ...float64) []types.Kline {
+    klines := make([]types.Kline, len(volumes))
+    for i, v := range volumes {
+        open := start.Add(time.Duration(i) * time.Minute)
+        klines[i] = types.Kline{OpenTime: open, CloseTime: open.Add(time.Minute - time.Millisecond), QuoteVolume: v}
+    }
+    return klines
+}
+
+func TestWindowVolumes(t *testing.T) {
+    start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+    tests := []struct {
+        name       string
+        volumes    []float64
+        bars       int
+        now        time.Time
+        wantRecent float64
+        wantPrior  float64
+        wantOK     bool
+    }{
+        {
+            name:    "all bars closed",
+            volumes: []float64{5, 10, 10, 20, 20},
+            bars:    2, now: start.Add(10 * time.Minute),
+            wantRecent: 40, wantPrior: 20, wantOK: true,
+        },
+        {
+            name:    "open bar left out",
+            volumes: []float64{10, 10, 20, 20, 1000},
+            bars:    2, now: start.Add(4*time.Minute + 30*time.Second),
+            wantRecent: 40, wantPrior: 20, wantOK: true,
+        },
+        {
+            name:    "not enough history",
+            volumes: []float64{10, 10, 20},
+            bars:    2, now: start.Add(10 * time.Minute),
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            recent, prior, ok := windowVolumes(minuteKlines(start, tt.volumes...), tt.bars, tt.now)
+            if ok != tt.wantOK || recent != tt.wantRecent || prior != tt.wantPrior {
+                t.Errorf("windowVolumes = %.0f, %.0f, %v; want %.0f, %.0f, %v",
+                    recent, prior, ok, tt.wantRecent, tt.wantPrior, tt.wantOK)
+            }
+        })
+    }
+}
+
+func TestMarketPairsExcludeStablecoinsAndLeveragedTokens(t *testing.T) {
+    tickers := []types.Ticker{
+        {Symbol: "BTCUSDT", PriceChangePercent: 2, LastPrice: 60000},
+        {Symbol: "ETHUSDT", PriceChangePercent: -1, LastPrice: 3000},
+        {Symbol: "SOLUSDT", PriceChangePercent: 3, LastPrice: 150},
+        {Symbol: "USDCUSDT", PriceChangePercent: -0.01, LastPrice: 1},
+        {Symbol: "FDUSDUSDT", PriceChangePercent: -0.01, LastPrice: 1},
+        {Symbol: "BTCDOWNUSDT", PriceChangePercent: -4, LastPrice: 0.01},
+        {Symbol: "ETHBTC", PriceChangePercent: -3, LastPrice: 0.05},
+    }
+
+    config := &types.Config{}
+    config.Strategy.Universe.Whitelist = []string{"SOL"} // Must not narrow the market
+    u, err := NewUniverse(config, nil)
+    if err != nil {
+        t.Fatalf("NewUniverse: %v", err)
+    }
+    // Preloaded exchangeInfo so no request is made
+    u.symbols = map[string]types.SymbolInfo{}
+    u.bases = map[string]bool{}
+    for _, tk := range tickers {
+        base, quote := strings.TrimSuffix(tk.Symbol, "USDT"), "USDT"
+        if tk.Symbol == "ETHBTC" {
+            base, quote = "ETH", "BTC"
+        }
+        u.symbols[tk.Symbol] = types.SymbolInfo{Symbol: tk.Symbol, Status: "TRADING", BaseAsset: base, QuoteAsset: quote}
+        u.bases[base] = true
+    }
+    u.refreshed = time.Now()
+
+    pairs := u.MarketPairs(tickers, "USDT")
+    got := make([]string, len(pairs))
+    for i, p := range pairs {
+        got[i] = p.Symbol
+    }
+    if strings.Join(got, ",") != "BTCUSDT,ETHUSDT,SOLUSDT" {
+        t.Fatalf("MarketPairs = %v, want BTCUSDT, ETHUSDT, SOLUSDT", got)
+    }
+
+    var state types.MarketState
+    ComputeBreadth(&state, pairs, "USDT")
+    if state.Advancers != 2 || state.Decliners != 1 {
+        t.Errorf("advancers/decliners = %d/%d, want 2/1", state.Advancers, state.Decliners)
+    }
+}
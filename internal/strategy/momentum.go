@@ -5,60 +5,147 @@ package strategy
 import (
     "binance-trading-bot/pkg/types"
     "binance-trading-bot/internal/binance"
+    "binance-trading-bot/internal/ml"
     "fmt"
     "log"
-    "sort"
+    "strings"
+    "sync"
+    "time"
 )
 
 type MomentumStrategy struct {
     config        *types.Config
     client        *binance.Client
+    rules         *RuleEngine
+    universe      *Universe
+    hotFilter     *HotFilter
+    rvol          *RVOLScanner
+    listings      *listingTracker
+    ranker        *Ranker
+    model         ml.Model
+    featureExport *ml.FeatureWriter
+    market        *types.MarketState
+    historyMu     sync.Mutex // GenerateSignal runs concurrently for different symbols
     priceHistory  map[string][]float64
     volumeHistory map[string][]float64
 }
 
-func NewMomentumStrategy(config *types.Config, client *binance.Client) *MomentumStrategy {
+func NewMomentumStrategy(config *types.Config, client *binance.Client) (*MomentumStrategy, error) {
+    rules, err := NewRuleEngine(config)
+    if err != nil {
+        return nil, fmt.Errorf("invalid strategy rules: %v", err)
+    }
+    
+    universe, err := NewUniverse(config, client)
+    if err != nil {
+        return nil, fmt.Errorf("invalid universe config: %v", err)
+    }
+    
+    hotFilter, err := NewHotFilter(config, client)
+    if err != nil {
+        return nil, fmt.Errorf("invalid hot window config: %v", err)
+    }
+    
+    if anomaly := config.Strategy.Anomaly; anomaly.Enabled && anomalyKlines(anomaly.LookbackBars) > 1000 {
+        return nil, fmt.Errorf("invalid anomaly config: lookback_bars %d needs %d klines, Binance returns at most 1000",
+            anomaly.LookbackBars, anomalyKlines(anomaly.LookbackBars))
+    }
+    
+    var rvol *RVOLScanner
+    if config.Strategy.RVOL.Enabled {
+        if rvol, err = NewRVOLScanner(config, client); err != nil {
+            return nil, fmt.Errorf("invalid rvol config: %v", err)
+        }
+    }
+    
+    ranker, err := NewRanker(config, client)
+    if err != nil {
+        return nil, fmt.Errorf("invalid ranking config: %v", err)
+    }
+    
+    var model ml.Model
+    if config.Strategy.ML.Enabled {
+        if model, err = ml.LoadModel(config.Strategy.ML.ModelPath); err != nil {
+            return nil, err
+        }
+        log.Printf("🤖 Loaded scoring model %s (%d features, %s mode)", 
+            config.Strategy.ML.ModelPath, len(model.Features()), config.Strategy.ML.Mode)
+    }
+    
+    var featureExport *ml.FeatureWriter
+    if config.Strategy.ML.FeatureExportPath != "" {
+        if featureExport, err = ml.NewFeatureWriter(config.Strategy.ML.FeatureExportPath); err != nil {
+            return nil, err
+        }
+    }
+    
     return &MomentumStrategy{
         config:        config,
         client:        client,
+        rules:         rules,
+        universe:      universe,
+        hotFilter:     hotFilter,
+        rvol:          rvol,
+        listings:      newListingTracker(),
+        ranker:        ranker,
+        model:         model,
+        featureExport: featureExport,
         priceHistory:  make(map[string][]float64),
         volumeHistory: make(map[string][]float64),
-    }
+    }, nil
 }
 
 func (s *MomentumStrategy) FindHotCoins(tickers []types.Ticker) []types.Ticker {
     var hotCoins []types.Ticker
+    var btc types.Ticker
     
     for _, ticker := range tickers {
-        // Only USDT pairs
-        if len(ticker.Symbol) < 4 || ticker.Symbol[len(ticker.Symbol)-4:] != "USDT" {
-            continue
+        if ticker.Symbol == "BTCUSDT" {
+            btc = ticker
+            break
         }
-        
-        // Volume filter
-        if ticker.QuoteVolume < s.config.Strategy.MinVolume {
+    }
+    
+    // Configured quote assets, allow/deny lists, no stablecoins or leveraged tokens
+    for _, ticker := range s.universe.Filter(tickers) {
+        // Volume filter (in the notional asset)
+        if ticker.NotionalVolume < s.config.Strategy.MinVolume {
             continue
         }
         
-        // Price change filter
-        if ticker.PriceChangePercent < s.config.Strategy.MinPriceChange {
+        // Price change filter (the rolling-window filter replaces it)
+        if s.hotFilter == nil && ticker.PriceChangePercent < s.config.Strategy.MinPriceChange {
             continue
         }
         
         hotCoins = append(hotCoins, ticker)
     }
     
-    // NEW: Better composite scoring for ranking
-    sort.Slice(hotCoins, func(i, j int) bool {
-        // Weight price change more heavily, but also consider volume
-        scoreI := (hotCoins[i].PriceChangePercent * 2.0) + (hotCoins[i].QuoteVolume / 1000000)
-        scoreJ := (hotCoins[j].PriceChangePercent * 2.0) + (hotCoins[j].QuoteVolume / 1000000)
-        return scoreI > scoreJ
-    })
+    // Rolling-window changes and volumes, e.g. +3% in 1h on 2x volume
+    if s.hotFilter != nil {
+        hotCoins = s.hotFilter.Filter(hotCoins)
+    }
     
-    // Keep top 10
-    if len(hotCoins) > 10 {
-        hotCoins = hotCoins[:10]
+    // Volume judged against what is normal for this hour of the day
+    if s.rvol != nil {
+        hotCoins = s.filterRVOL(hotCoins)
+    }
+    
+    // Rank with the configured formula (relative strength vs BTC, beta, percentile)
+    ranks := s.ranker.Rank(hotCoins, btc)
+    
+    topN := s.config.Strategy.Ranking.TopN
+    if topN <= 0 {
+        topN = 10
+    }
+    if len(ranks) > topN {
+        ranks = ranks[:topN]
+    }
+    
+    hotCoins = make([]types.Ticker, len(ranks))
+    for i, rank := range ranks {
+        hotCoins[i] = rank.Ticker
+        log.Printf("   🏅 %d. %s: %s", i+1, rank.Ticker.Symbol, rank.Describe())
     }
     
     return hotCoins
@@ -85,18 +172,13 @@ func (s *MomentumStrategy) AnalyzeMultipleTimeframes(symbol string) ([]types.Tim
             continue
         }
         
-        closes := make([]float64, len(klines))
-        volumes := make([]float64, len(klines))
-        
-        for i, k := range klines {
-            closes[i] = k.Close
-            volumes[i] = k.Volume
-        }
-        
-        trend, strength := DetectTrend(klines)
-        rsi := CalculateRSI(closes, 14)
-        macd, signal, histogram := CalculateMACD(closes)
-        upperBB, middleBB, lowerBB := CalculateBollingerBands(closes, 20, 2.0)
+        // One indicator pass shared by the trend score and the report
+        ind := ComputeTrendIndicators(klines)
+        closes, volumes := ind.Closes, ind.Volumes
+        trend, strength := DetectTrendFrom(ind)
+        rsi := ind.RSI
+        macd, signal, histogram := ind.MACD, ind.MACDSignal, ind.MACDHistogram
+        upperBB, middleBB, lowerBB := ind.UpperBB, ind.MiddleBB, ind.LowerBB
         atr := CalculateATR(klines, 14)
         momentumScore := CalculateMomentumScore(closes, volumes)
         
@@ -109,6 +191,14 @@ func (s *MomentumStrategy) AnalyzeMultipleTimeframes(symbol string) ([]types.Tim
             Signal:    signal,
         }
         
+        if s.config.Strategy.Divergence.Enabled {
+            analysis.Divergences = DetectAllDivergences(ClosedKlines(klines), tf,
+                s.config.Strategy.Divergence.Indicators, DivergenceConfigFrom(s.config))
+            for _, d := range analysis.Divergences {
+                log.Printf("   ↕️  %s", DescribeDivergence(d))
+            }
+        }
+        
         analyses = append(analyses, analysis)
         validTimeframes++
         
@@ -154,6 +244,9 @@ func (s *MomentumStrategy) AnalyzeMultipleTimeframes(symbol string) ([]types.Tim
 }
 
 func (s *MomentumStrategy) UpdateHistory(symbol string, price, volume float64) {
+    s.historyMu.Lock()
+    defer s.historyMu.Unlock()
+    
     if s.priceHistory[symbol] == nil {
         s.priceHistory[symbol] = make([]float64, 0)
         s.volumeHistory[symbol] = make([]float64, 0)
@@ -168,6 +261,31 @@ func (s *MomentumStrategy) UpdateHistory(symbol string, price, volume float64) {
     }
 }
 
+// History returns copies of the 1m price and volume history for a symbol
+func (s *MomentumStrategy) History(symbol string) ([]float64, []float64) {
+    s.historyMu.Lock()
+    defer s.historyMu.Unlock()
+    
+    prices := append([]float64(nil), s.priceHistory[symbol]...)
+    volumes := append([]float64(nil), s.volumeHistory[symbol]...)
+    return prices, volumes
+}
+
+// setHistory replaces the history of a symbol, e.g. after loading klines
+func (s *MomentumStrategy) setHistory(symbol string, prices, volumes []float64) {
+    s.historyMu.Lock()
+    defer s.historyMu.Unlock()
+    
+    s.priceHistory[symbol] = prices
+    s.volumeHistory[symbol] = volumes
+}
+
+// SymbolInfo returns a symbol's exchangeInfo entry, including its order
+// filters once exchangeInfo has loaded
+func (s *MomentumStrategy) SymbolInfo(symbol string) (types.SymbolInfo, bool) {
+    return s.universe.symbolInfo(symbol)
+}
+
 func (s *MomentumStrategy) GenerateSignal(ticker types.Ticker, positions []types.Position) types.Signal {
     signal := types.Signal{
         Symbol:    ticker.Symbol,
@@ -176,32 +294,27 @@ func (s *MomentumStrategy) GenerateSignal(ticker types.Ticker, positions []types
         Timestamp: ticker.Timestamp,
         Strength:  0,
         MTFScore:  0.5,
+        Strategy:  "momentum",
+        
+        QuoteAsset:   ticker.QuoteAsset,
+        NotionalRate: ticker.NotionalRate,
     }
     
     // Update history
     s.UpdateHistory(ticker.Symbol, ticker.LastPrice, ticker.Volume)
     
-    prices := s.priceHistory[ticker.Symbol]
-    volumes := s.volumeHistory[ticker.Symbol]
+    prices, volumes := s.History(ticker.Symbol)
     
     // Fetch historical data if needed
     if len(prices) < 20 {
         log.Printf("   📥 Fetching recent price history for %s...", ticker.Symbol)
         klines, err := s.client.GetKlines(ticker.Symbol, "1m", 50)
         if err == nil && len(klines) > 0 {
-            s.priceHistory[ticker.Symbol] = make([]float64, 0)
-            s.volumeHistory[ticker.Symbol] = make([]float64, 0)
-            
-            for _, k := range klines {
-                s.priceHistory[ticker.Symbol] = append(s.priceHistory[ticker.Symbol], k.Close)
-                s.volumeHistory[ticker.Symbol] = append(s.volumeHistory[ticker.Symbol], k.Volume)
-            }
-            
-            s.priceHistory[ticker.Symbol] = append(s.priceHistory[ticker.Symbol], ticker.LastPrice)
-            s.volumeHistory[ticker.Symbol] = append(s.volumeHistory[ticker.Symbol], ticker.Volume)
+            prices = append(ClosePrices(klines), ticker.LastPrice)
+            volumes = append(Volumes(klines), ticker.Volume)
+            s.setHistory(ticker.Symbol, prices, volumes)
             
-            prices = s.priceHistory[ticker.Symbol]
-            volumes = s.volumeHistory[ticker.Symbol]
+            prices, volumes = s.History(ticker.Symbol)
             log.Printf("   ✅ Loaded %d historical prices", len(prices))
         } else {
             signal.Reason = "Failed to fetch price history"
@@ -220,307 +333,350 @@ func (s *MomentumStrategy) GenerateSignal(ticker types.Ticker, positions []types
     }
     
     // Get klines for advanced analysis
-    klines, err := s.client.GetKlines(ticker.Symbol, "5m", 50)
-    var regime string
-    var regimeConfidence float64
-    var volumeProfile string
-    var volumeStrength float64
-    var atrValue float64
-    
-    if err == nil && len(klines) > 0 {
-        // NEW: Market regime detection
-        regime, regimeConfidence = DetectMarketRegime(klines)
-        log.Printf("   📈 Market Regime: %s (%.0f%% confidence)", regime, regimeConfidence*100)
-        
-        // NEW: Volume profile analysis
-        volumeProfile, volumeStrength = AnalyzeVolumeProfile(klines, 20)
-        log.Printf("   📊 Volume Profile: %s (%.0f%% strength)", volumeProfile, volumeStrength*100)
-        
-        // Get ATR for volatility
-        atrValue = CalculateATR(klines, 14)
-    } else {
-        regime = "UNKNOWN"
-        regimeConfidence = 0.5
-        volumeProfile = "NEUTRAL"
-        volumeStrength = 0.5
-        atrValue = 0
-    }
-    
-    // Calculate indicators on 1-minute data
-    var rsi float64
-    if len(prices) >= 15 {
-        rsi = CalculateRSI(prices, 14)
-    } else {
-        rsi = 50.0
+    klines, err := s.client.GetKlines(ticker.Symbol, "5m", 100)
+    if err != nil {
+        klines = nil
     }
+    ctx := s.buildContext(ticker, prices, volumes, klines)
     
-    sma20 := CalculateSMA(prices, 20)
-    ema12 := CalculateEMA(prices, 12)
-    ema26 := CalculateEMA(prices, 26)
-    macd, macdSignal, macdHistogram := CalculateMACD(prices)
-    upperBB, middleBB, lowerBB := CalculateBollingerBands(prices, 20, 2.0)
-    
-    // Volume analysis on 1-minute data
-    var currentVolume float64
-    var volumeSpike bool
-    var volumeRatio float64
-    
-    if len(volumes) > 1 {
-        currentVolume = volumes[len(volumes)-1]
-        volumeSpike, volumeRatio = DetectVolumeSpike(volumes[:len(volumes)-1], currentVolume)
-    } else {
-        currentVolume = ticker.Volume
-        volumeSpike = false
-        volumeRatio = 1.0
+    // Relative volume for this hour of the day (measured in FindHotCoins when possible)
+    if s.rvol != nil {
+        ctx.RVOL = ticker.RVOL
+        if ctx.RVOL == 0 {
+            if rvol, err := s.rvol.RVOL(ticker.Symbol); err == nil {
+                ctx.RVOL = rvol
+            } else {
+                log.Printf("   ⚠️  RVOL unavailable: %v", err)
+            }
+        }
+        if ctx.RVOL > 0 {
+            multiplier := s.config.Strategy.VolumeSpikeMultiplier
+            if multiplier <= 0 {
+                multiplier = 1.5
+            }
+            ctx.VolumeSpike = ctx.RVOL >= multiplier
+            log.Printf("   📊 RVOL: %.2fx time-of-day volume (simple ratio %.2fx)", ctx.RVOL, ctx.VolumeRatio)
+        }
     }
     
     // Multi-timeframe analysis
-    var mtfScore float64
-    var mtfAnalyses []types.TimeframeAnalysis
-    
     if s.config.Strategy.UseMultiTimeframe {
         log.Printf("   🔬 Multi-timeframe analysis:")
-        mtfAnalyses, mtfScore = s.AnalyzeMultipleTimeframes(ticker.Symbol)
+        ctx.MTFAnalyses, ctx.MTFScore = s.AnalyzeMultipleTimeframes(ticker.Symbol)
     } else {
-        mtfScore = 0.6
+        ctx.MTFScore = 0.6
     }
     
-    signal.MTFScore = mtfScore
+    signal.MTFScore = ctx.MTFScore
     
     // Only generate BUY signals if we don't have a position
     if !hasPosition {
-        // === ENTRY CRITERIA (Multiple Confirmations) ===
-        
-        // 1. Momentum
-        momentumStrong := ticker.PriceChangePercent >= s.config.Strategy.MinPriceChange
-        
-        // 2. Volume
-        volumeGood := ticker.QuoteVolume >= s.config.Strategy.MinVolume
-        volumeConfirmation := volumeSpike && volumeRatio > 1.5
-        
-        // NEW: Volume profile confirmation
-        volumeProfileBullish := volumeProfile == "ACCUMULATION"
-        
-        // 3. RSI - Not overbought, ideally in sweet spot
-        rsiHealthy := rsi >= 40 && rsi <= 75
-        rsiOptimal := rsi >= 45 && rsi <= 65
-        rsiNotExtreme := rsi > 5 && rsi < 95
-        
-        // 4. Price action
-        aboveSMA := ticker.LastPrice > sma20*0.98
-        bullishEMA := ema12 > ema26
-        
-        // 5. MACD
-        macdBullish := macd > macdSignal
-        macdPositive := macdHistogram > 0
-        
-        // 6. Bollinger Bands
-        bbPosition := ticker.LastPrice > lowerBB && ticker.LastPrice < upperBB
-        bbBullish := ticker.LastPrice > middleBB
-        
-        // 7. Multi-timeframe
-        mtfBullish := mtfScore > 0.50
-        mtfStrong := mtfScore > 0.65
-        
-        // NEW: 8. Market regime filter
-        regimeFavorable := regime == "TRENDING" || regime == "TRANSITIONING"
-        regimeHighConfidence := regimeConfidence > 0.6
-        
-        // Log all criteria
-        log.Printf("   ✅ Momentum: %v (+%.2f%% >= %.1f%%)", 
-            momentumStrong, ticker.PriceChangePercent, s.config.Strategy.MinPriceChange)
-        log.Printf("   ✅ Volume: %v ($%.0f >= $%.0f) | Spike: %v (%.1fx) | Profile: %s", 
-            volumeGood, ticker.QuoteVolume, s.config.Strategy.MinVolume, volumeSpike, volumeRatio, volumeProfile)
-        log.Printf("   ✅ RSI: Healthy=%v Optimal=%v NotExtreme=%v (%.1f)", 
-            rsiHealthy, rsiOptimal, rsiNotExtreme, rsi)
-        log.Printf("   ✅ Price: AboveSMA=%v ($%.4f vs $%.4f) | BullishEMA=%v", 
-            aboveSMA, ticker.LastPrice, sma20, bullishEMA)
-        log.Printf("   ✅ MACD: Bullish=%v Positive=%v (%.4f)", macdBullish, macdPositive, macdHistogram)
-        log.Printf("   ✅ BB: InRange=%v AboveMid=%v ($%.4f/$%.4f/$%.4f)", 
-            bbPosition, bbBullish, lowerBB, middleBB, upperBB)
-        log.Printf("   ✅ MTF: Bullish=%v Strong=%v (%.2f)", mtfBullish, mtfStrong, mtfScore)
-        log.Printf("   ✅ Regime: Favorable=%v HighConf=%v (%s)", regimeFavorable, regimeHighConfidence, regime)
-        
-        // === SCORING SYSTEM (Enhanced) ===
-        score := 0.0
-        maxScore := 0.0
-        reasons := []string{}
-        
-        // Must-have criteria (60% of score)
-        if momentumStrong {
-            score += 15
-            reasons = append(reasons, fmt.Sprintf("+%.1f%% momentum", ticker.PriceChangePercent))
-        }
-        maxScore += 15
-        
-        if volumeGood {
-            score += 15
-            if volumeConfirmation {
-                score += 5
-                reasons = append(reasons, fmt.Sprintf("%.1fx volume spike", volumeRatio))
-            }
-        }
-        maxScore += 20
-        
-        // NEW: Volume profile adds to score
-        if volumeProfileBullish {
-            score += 5
-            reasons = append(reasons, "accumulation phase")
-        }
-        maxScore += 5
-        
-        if rsiHealthy && rsiNotExtreme {
-            score += 10
-            if rsiOptimal {
-                score += 5
-                reasons = append(reasons, fmt.Sprintf("optimal RSI (%.1f)", rsi))
-            } else {
-                reasons = append(reasons, fmt.Sprintf("healthy RSI (%.1f)", rsi))
+        // Fetch klines for any timeframes referenced by custom expressions
+        ctx.Timeframes = s.fetchTimeframes(ticker.Symbol, s.rules.Timeframes())
+        
+        // Pump-and-dump / wash-trading check (vetoed by the anomaly rule)
+        if s.config.Strategy.Anomaly.Enabled {
+            report := s.DetectAnomalies(ticker)
+            ctx.Anomaly = &report
+            signal.Anomaly = &report
+            if report.Flagged {
+                log.Printf("   🚩 Anomaly: %s", DescribeAnomaly(&report))
             }
         }
-        maxScore += 15
         
-        if mtfBullish {
-            score += 10
-            if mtfStrong {
-                score += 10
-            }
+        // === ENTRY RULES (configured in strategy.entry_rules) ===
+        eval := s.rules.Evaluate(ctx)
+        signal.Strength = eval.Strength
+        signal.Regime = ctx.Regime
+        signal.ATR = ctx.ATR
+        
+        // Scoring model blends with or replaces the rule strength
+        features := ExtractFeatures(ctx, eval)
+        var probability *float64
+        if s.model != nil {
+            p := s.model.Predict(features)
+            probability = &p
+            signal.Strength = s.modelStrength(eval.Strength, p)
         }
-        maxScore += 20
+        defer s.exportFeatures(&signal, features, eval.Strength, probability)
         
-        // Nice-to-have criteria (40% of score)
-        if aboveSMA {
-            score += 5
-            reasons = append(reasons, "above SMA20")
+        // Dynamic threshold based on market regime
+        threshold := s.rules.Threshold(ctx.Regime)
+        notes := []string{}
+        if threshold != s.rules.baseThreshold {
+            notes = append(notes, fmt.Sprintf("%s market - using threshold %.0f%%", ctx.Regime, threshold*100))
         }
-        maxScore += 5
         
-        if bullishEMA {
-            score += 5
-            reasons = append(reasons, "bullish EMA crossover")
+        // Demand more in risk-off market conditions
+        if ctx.Market != nil && ctx.Market.RiskOff && s.config.Strategy.MarketFilter.RiskOffThresholdBoost > 0 {
+            threshold += s.config.Strategy.MarketFilter.RiskOffThresholdBoost
+            notes = append(notes, fmt.Sprintf("Risk-off market (%s) - threshold raised to %.0f%%", 
+                strings.Join(ctx.Market.RiskOffReasons, ", "), threshold*100))
         }
-        maxScore += 5
         
-        if macdBullish && macdPositive {
-            score += 10
-            reasons = append(reasons, "MACD bullish")
-        }
-        maxScore += 10
+        explanation := NewSignalExplanation(eval, ctx, threshold)
+        explanation.Strength = signal.Strength
+        explanation.ModelProbability = probability
+        explanation.Notes = notes
+        signal.Explanation = explanation
         
-        if bbPosition && bbBullish {
-            score += 5
+        for _, line := range ExplanationLines(explanation) {
+            log.Printf("   %s", line)
         }
-        maxScore += 5
-        
-        // NEW: Regime scoring
-        if regimeFavorable && regimeHighConfidence {
-            score += 10
-            reasons = append(reasons, fmt.Sprintf("%s regime", regime))
-        } else if regime == "VOLATILE" {
-            // Penalize volatile markets
-            score -= 5
-            reasons = append(reasons, "volatile market (-5pts)")
-        } else if regime == "RANGING" {
-            // Slight penalty for ranging markets
-            score -= 3
-        }
-        maxScore += 10
-        
-        // Calculate final strength
-        signal.Strength = score / maxScore
-        
-        log.Printf("   📊 SCORE: %.0f/%.0f (%.1f%%)", score, maxScore, signal.Strength*100)
         
-        // NEW: Dynamic threshold based on market regime
-        threshold := 0.60
-        if regime == "VOLATILE" {
-            threshold = 0.75  // Require much higher score in volatile markets
-            log.Printf("   ⚠️  Volatile market detected - raising threshold to %.0f%%", threshold*100)
-        } else if regime == "TRENDING" {
-            threshold = 0.55  // Can be slightly more aggressive in trending markets
-            log.Printf("   ✅ Trending market - lowering threshold to %.0f%%", threshold*100)
-        } else if regime == "RANGING" {
-            threshold = 0.70  // Need strong signal in ranging markets
-            log.Printf("   ⚠️  Ranging market detected - raising threshold to %.0f%%", threshold*100)
-        }
-        
-        // CRITICAL: Reject if RSI is at extremes
-        if !rsiNotExtreme {
-            signal.Reason = fmt.Sprintf("Extreme RSI detected (%.1f) - rejecting signal for safety", rsi)
+        // CRITICAL: Reject on failed required rules or triggered vetoes
+        if eval.Rejected {
+            signal.Reason = ExplanationReason(signal.Action, explanation)
             log.Printf("   🚫 REJECTED: %s", signal.Reason)
             return signal
         }
         
         if signal.Strength >= threshold {
             signal.Action = "BUY"
+            signal.Reason = ExplanationReason(signal.Action, explanation)
             
-            // Build detailed reason
-            reason := fmt.Sprintf("Score: %.0f%% | ", signal.Strength*100)
-            for i, r := range reasons {
-                if i > 0 {
-                    reason += ", "
-                }
-                reason += r
+            // Support/resistance levels for stop loss and take profit placement
+            supports, resistances := CalculateSupportResistance(ClosedKlines(ctx.Klines5m))
+            signal.Levels = append(supports, resistances...)
+            support, resistance := NearestLevels(signal.Levels, signal.Price, 1)
+            if support.Price > 0 {
+                log.Printf("   🧱 Nearest %s", DescribeLevel(support))
             }
-            
-            reason += fmt.Sprintf("\n   RSI: %.1f | BB: $%.4f-$%.4f", rsi, lowerBB, upperBB)
-            reason += fmt.Sprintf("\n   MTF: %.0f%% (", mtfScore*100)
-            
-            if len(mtfAnalyses) > 0 {
-                for i, a := range mtfAnalyses {
-                    if i > 0 {
-                        reason += ", "
-                    }
-                    reason += fmt.Sprintf("%s:%s", a.Timeframe, a.Trend)
-                }
-                reason += ")"
-            } else {
-                reason += "No MTF)"
+            if resistance.Price > 0 {
+                log.Printf("   🧱 Nearest %s", DescribeLevel(resistance))
             }
             
-            signal.Reason = reason
-            
-            // NEW: Store ATR in signal for risk management
-            signal.ATR = atrValue
-            
             log.Printf("   🎯 BUY SIGNAL GENERATED - Strength: %.0f%%", signal.Strength*100)
             
         } else {
             // Explain why score is too low
-            missing := []string{}
-            if !momentumStrong {
-                missing = append(missing, fmt.Sprintf("weak momentum (%.2f%%)", ticker.PriceChangePercent))
-            }
-            if !volumeGood {
-                missing = append(missing, fmt.Sprintf("low volume ($%.0f)", ticker.QuoteVolume))
-            }
-            if !rsiHealthy {
-                missing = append(missing, fmt.Sprintf("poor RSI (%.1f)", rsi))
-            }
-            if !mtfBullish {
-                missing = append(missing, fmt.Sprintf("MTF bearish (%.2f)", mtfScore))
+            signal.Reason = ExplanationReason(signal.Action, explanation)
+            log.Printf("   ⛔ No signal: %s", signal.Reason)
+        }
+    } else {
+        signal.Reason = "Already have position"
+        log.Printf("   ⏭️  Skipping: Already have position in %s", ticker.Symbol)
+    }
+    
+    return signal
+}
+
+// filterRVOL measures the RVOL of each coin and drops those below min_rvol.
+// Coins whose RVOL cannot be measured are kept.
+func (s *MomentumStrategy) filterRVOL(coins []types.Ticker) []types.Ticker {
+    s.rvol.Prune()
+    minRVOL := s.config.Strategy.RVOL.MinRVOL
+    
+    kept := coins[:0]
+    for _, coin := range coins {
+        rvol, err := s.rvol.RVOL(coin.Symbol)
+        if err != nil {
+            log.Printf("   ⚠️  RVOL %s: %v", coin.Symbol, err)
+            kept = append(kept, coin)
+            continue
+        }
+        coin.RVOL = rvol
+        if minRVOL > 0 && rvol < minRVOL {
+            continue
+        }
+        kept = append(kept, coin)
+    }
+    
+    if minRVOL > 0 {
+        log.Printf("📊 RVOL filter: %d of %d coins at %.1fx or more of their time-of-day volume", 
+            len(kept), len(coins), minRVOL)
+    }
+    return kept
+}
+
+// modelStrength combines the rule strength with the model probability
+func (s *MomentumStrategy) modelStrength(ruleStrength, probability float64) float64 {
+    if s.config.Strategy.ML.Mode == "replace" {
+        return probability
+    }
+    weight := s.config.Strategy.ML.BlendWeight
+    if weight <= 0 || weight > 1 {
+        weight = 0.5
+    }
+    return (1-weight)*ruleStrength + weight*probability
+}
+
+// exportFeatures appends the evaluated features for offline training
+func (s *MomentumStrategy) exportFeatures(signal *types.Signal, features map[string]float64, ruleStrength float64, probability *float64) {
+    if s.featureExport == nil {
+        return
+    }
+    err := s.featureExport.Write(ml.FeatureRecord{
+        Time:             time.Now(),
+        Symbol:           signal.Symbol,
+        Price:            signal.Price,
+        Action:           signal.Action,
+        RuleStrength:     ruleStrength,
+        ModelProbability: probability,
+        Features:         features,
+    })
+    if err != nil {
+        log.Printf("   ⚠️  Feature export failed: %v", err)
+    }
+}
+
+// buildContext computes every indicator used by the entry rules from 1m price
+// history and 5m klines
+func (s *MomentumStrategy) buildContext(ticker types.Ticker, prices, volumes []float64, klines []types.Kline) *SignalContext {
+    ctx := &SignalContext{
+        Config:   s.config,
+        Ticker:   ticker,
+        Prices:   prices,
+        Volumes:  volumes,
+        Klines5m: klines,
+        MTFScore: 0.5,
+        Market:   s.market,
+    }
+    
+    if len(klines) > 0 {
+        // NEW: Market regime detection
+        ctx.Regime, ctx.RegimeConfidence = DetectMarketRegime(klines)
+        log.Printf("   📈 Market Regime: %s (%.0f%% confidence)", ctx.Regime, ctx.RegimeConfidence*100)
+        
+        // NEW: Volume profile analysis
+        ctx.VolumeProfile, ctx.VolumeStrength = AnalyzeVolumeProfile(klines, 20)
+        log.Printf("   📊 Volume Profile: %s (%.0f%% strength)", ctx.VolumeProfile, ctx.VolumeStrength*100)
+        
+        // Get ATR for volatility
+        ctx.ATR = CalculateATR(klines, 14)
+        
+        // Candlestick patterns on the last closed candle
+        ctx.Patterns = DetectPatterns(ClosedKlines(klines))
+        if len(ctx.Patterns) > 0 {
+            log.Printf("   🕯️  Patterns: %s", PatternNames(ctx.Patterns))
+        }
+    } else {
+        ctx.Regime = "UNKNOWN"
+        ctx.RegimeConfidence = 0.5
+        ctx.VolumeProfile = "NEUTRAL"
+        ctx.VolumeStrength = 0.5
+    }
+    
+    // Calculate indicators on 1-minute data
+    if len(prices) >= 15 {
+        ctx.RSI = CalculateRSI(prices, 14)
+    } else {
+        ctx.RSI = 50.0
+    }
+    
+    ctx.SMA20 = CalculateSMA(prices, 20)
+    ctx.EMA12 = CalculateEMA(prices, 12)
+    ctx.EMA26 = CalculateEMA(prices, 26)
+    ctx.MACD, ctx.MACDSignal, ctx.MACDHistogram = CalculateMACD(prices)
+    ctx.UpperBB, ctx.MiddleBB, ctx.LowerBB = CalculateBollingerBands(prices, 20, 2.0)
+    
+    // Volume analysis on 1-minute data
+    if len(volumes) > 1 {
+        ctx.VolumeSpike, ctx.VolumeRatio = DetectVolumeSpike(volumes[:len(volumes)-1], volumes[len(volumes)-1])
+    } else {
+        ctx.VolumeRatio = 1.0
+    }
+    
+    return ctx
+}
+
+// fetchTimeframes loads klines for every timeframe an expression needs
+func (s *MomentumStrategy) fetchTimeframes(symbol string, needed map[string]int) map[string][]types.Kline {
+    timeframes := make(map[string][]types.Kline, len(needed))
+    for tf, bars := range needed {
+        if bars < 100 {
+            bars = 100
+        }
+        klines, err := s.client.GetKlines(symbol, tf, bars)
+        if err != nil {
+            log.Printf("   ⚠️  Failed to get %s klines for expressions: %v", tf, err)
+            continue
+        }
+        timeframes[tf] = klines
+    }
+    return timeframes
+}
+
+// GenerateExitSignal checks an open position for strategy exits: a bearish
+// multi-timeframe flip, a 5m MACD cross down, a close below VWAP and the
+// configured exit expressions. Returns a SELL signal with the reason, or HOLD.
+func (s *MomentumStrategy) GenerateExitSignal(position types.Position) types.Signal {
+    signal := types.Signal{
+        Symbol:    position.Symbol,
+        Action:    "HOLD",
+        Price:     position.CurrentPrice,
+        Timestamp: time.Now(),
+    }
+    
+    cfg := s.config.Strategy.ExitSignals
+    if !cfg.Enabled && !s.rules.HasExitConditions() {
+        return signal
+    }
+    
+    if cfg.MinHoldMinutes > 0 && !position.EntryTime.IsZero() &&
+        time.Since(position.EntryTime) < time.Duration(cfg.MinHoldMinutes)*time.Minute {
+        return signal
+    }
+    
+    klines5m, err := s.client.GetKlines(position.Symbol, "5m", 100)
+    if err != nil {
+        log.Printf("   ⚠️  Could not fetch 5m klines for %s exit check: %v", position.Symbol, err)
+        klines5m = nil
+    }
+    closed := ClosedKlines(klines5m)
+    closes := ClosePrices(closed)
+    
+    reasons := []string{}
+    
+    if cfg.Enabled {
+        // MTF trend flip
+        if cfg.MTFFlip {
+            analyses, mtfScore := s.AnalyzeMultipleTimeframes(position.Symbol)
+            signal.MTFScore = mtfScore
+            if len(analyses) > 0 && mtfScore < cfg.MTFExitScore {
+                reasons = append(reasons, fmt.Sprintf("MTF flipped bearish (%.0f%% < %.0f%%)", mtfScore*100, cfg.MTFExitScore*100))
             }
-            if !aboveSMA {
-                missing = append(missing, "below SMA20")
+        }
+        
+        // MACD line crossed below its signal line on the last closed 5m candle
+        if cfg.MACDCrossDown && len(closes) > 0 {
+            macd, macdSignal, _ := MACDSeries(closes)
+            if CrossBelow(macd, macdSignal) {
+                reasons = append(reasons, "5m MACD crossed below signal")
             }
-            if !regimeFavorable {
-                missing = append(missing, fmt.Sprintf("unfavorable regime (%s)", regime))
+        }
+        
+        // Close lost the VWAP of the fetched 5m window
+        if cfg.VWAPLoss && len(closes) > 0 {
+            vwap := VWAPSeries(closed)
+            if CrossBelow(closes, vwap) {
+                reasons = append(reasons, fmt.Sprintf("5m close lost VWAP ($%.4f)", Last(vwap)))
             }
+        }
+    }
+    
+    // Configured exit expressions
+    if len(reasons) == 0 && s.rules.HasExitConditions() {
+        klines, err := s.client.GetKlines(position.Symbol, "1m", 50)
+        if err != nil || len(klines) == 0 {
+            log.Printf("   ⚠️  Could not fetch klines for %s exit check: %v", position.Symbol, err)
+        } else {
+            ticker := types.Ticker{Symbol: position.Symbol, LastPrice: position.CurrentPrice, Timestamp: time.Now()}
+            ctx := s.buildContext(ticker, ClosePrices(klines), Volumes(klines), klines5m)
+            ctx.Position = &position
+            ctx.Timeframes = s.fetchTimeframes(position.Symbol, s.rules.Timeframes())
             
-            signal.Reason = fmt.Sprintf("Score too low (%.0f%% < %.0f%%): ", signal.Strength*100, threshold*100)
-            for i, m := range missing {
-                if i > 0 {
-                    signal.Reason += ", "
-                }
-                signal.Reason += m
+            if hit, reason := s.rules.EvaluateExit(ctx); hit {
+                reasons = append(reasons, reason)
             }
-            
-            log.Printf("   ⛔ No signal: %s", signal.Reason)
         }
-    } else {
-        signal.Reason = "Already have position"
-        log.Printf("   ⏭️  Skipping: Already have position in %s", ticker.Symbol)
+    }
+    
+    if len(reasons) > 0 {
+        signal.Action = "SELL"
+        signal.Reason = strings.Join(reasons, ", ")
+        log.Printf("   🚪 EXIT SIGNAL for %s: %s", position.Symbol, signal.Reason)
     }
     
     return signal
-}
\ No newline at end of file
+}
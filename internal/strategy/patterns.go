@@ -0,0 +1,210 @@
+// File: internal/strategy/patterns.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "math"
+    "strings"
+    "time"
+)
+
+// PatternMatch is a candlestick pattern that completed on a given kline
+type PatternMatch struct {
+    Name       string
+    Direction  string  // "BULLISH", "BEARISH" or "NEUTRAL"
+    Confidence float64 // 0-1
+    Index      int     // Index of the kline that completed the pattern
+}
+
+func (p PatternMatch) String() string {
+    return fmt.Sprintf("%s (%.0f%%)", p.Name, p.Confidence*100)
+}
+
+// candle geometry helpers
+func body(k types.Kline) float64      { return math.Abs(k.Close - k.Open) }
+func candleRange(k types.Kline) float64 { return k.High - k.Low }
+func upperShadow(k types.Kline) float64 { return k.High - math.Max(k.Open, k.Close) }
+func lowerShadow(k types.Kline) float64 { return math.Min(k.Open, k.Close) - k.Low }
+func isBullish(k types.Kline) bool     { return k.Close > k.Open }
+func isBearish(k types.Kline) bool     { return k.Close < k.Open }
+func bodyMid(k types.Kline) float64    { return (k.Open + k.Close) / 2 }
+
+func clamp01(v float64) float64 {
+    return math.Max(0, math.Min(1, v))
+}
+
+// ClosedKlines drops the last kline if it is still forming
+func ClosedKlines(klines []types.Kline) []types.Kline {
+    if len(klines) > 0 && klines[len(klines)-1].CloseTime.After(time.Now()) {
+        return klines[:len(klines)-1]
+    }
+    return klines
+}
+
+// averageBody is the mean body size of the lookback klines before index i,
+// used to judge whether a candle is "long" or "small"
+func averageBody(klines []types.Kline, i, lookback int) float64 {
+    start := i - lookback
+    if start < 0 {
+        start = 0
+    }
+    if start >= i {
+        return body(klines[i])
+    }
+    sum := 0.0
+    for j := start; j < i; j++ {
+        sum += body(klines[j])
+    }
+    return sum / float64(i-start)
+}
+
+// priorTrend returns the close-to-close change over the bars before index i,
+// as a fraction of price (negative = downtrend)
+func priorTrend(klines []types.Kline, i, lookback int) float64 {
+    start := i - lookback
+    if start < 0 || klines[start].Close == 0 {
+        return 0
+    }
+    return (klines[i-1].Close - klines[start].Close) / klines[start].Close
+}
+
+// trendBonus scales confidence up when the pattern appears after a move in the
+// direction it is expected to reverse
+func trendBonus(trend float64, wantDown bool) float64 {
+    if wantDown {
+        trend = -trend
+    }
+    return clamp01(trend * 20) * 0.2 // A 1% move adds the full 0.2
+}
+
+// DetectPatterns returns the patterns that completed on the latest kline.
+// Pass closed klines only (see ClosedKlines) for reliable results.
+func DetectPatterns(klines []types.Kline) []PatternMatch {
+    if len(klines) == 0 {
+        return nil
+    }
+    return DetectPatternsAt(klines, len(klines)-1)
+}
+
+// DetectPatternsAt returns the patterns that completed on kline i
+func DetectPatternsAt(klines []types.Kline, i int) []PatternMatch {
+    if i < 1 || i >= len(klines) {
+        return nil
+    }
+
+    matches := []PatternMatch{}
+    add := func(name, direction string, confidence float64) {
+        matches = append(matches, PatternMatch{name, direction, clamp01(confidence), i})
+    }
+
+    cur, prev := klines[i], klines[i-1]
+    avgBody := averageBody(klines, i, 10)
+    if avgBody == 0 {
+        return matches
+    }
+    trend := priorTrend(klines, i, 5)
+
+    // Doji - open and close nearly equal
+    if r := candleRange(cur); r > 0 && body(cur) <= r*0.1 {
+        add("Doji", "NEUTRAL", 0.5+0.5*(1-body(cur)/(r*0.1)))
+    }
+
+    // Hammer - small body at the top, long lower shadow, after a decline
+    if r := candleRange(cur); r > 0 && body(cur) > 0 &&
+        lowerShadow(cur) >= 2*body(cur) && upperShadow(cur) <= body(cur)*0.5 && trend < 0 {
+        add("Hammer", "BULLISH", 0.4+clamp01(lowerShadow(cur)/r-0.5)+trendBonus(trend, true))
+    }
+
+    // Shooting star - small body at the bottom, long upper shadow, after a rally
+    if r := candleRange(cur); r > 0 && body(cur) > 0 &&
+        upperShadow(cur) >= 2*body(cur) && lowerShadow(cur) <= body(cur)*0.5 && trend > 0 {
+        add("Shooting Star", "BEARISH", 0.4+clamp01(upperShadow(cur)/r-0.5)+trendBonus(trend, false))
+    }
+
+    // Engulfing - body fully covers the previous opposite body
+    if isBearish(prev) && isBullish(cur) && cur.Open <= prev.Close && cur.Close >= prev.Open && body(cur) > body(prev) {
+        add("Bullish Engulfing", "BULLISH", 0.5+clamp01(body(cur)/avgBody-1)*0.3+trendBonus(trend, true))
+    }
+    if isBullish(prev) && isBearish(cur) && cur.Open >= prev.Close && cur.Close <= prev.Open && body(cur) > body(prev) {
+        add("Bearish Engulfing", "BEARISH", 0.5+clamp01(body(cur)/avgBody-1)*0.3+trendBonus(trend, false))
+    }
+
+    // Inside bar - range contained within the previous range
+    if cur.High < prev.High && cur.Low > prev.Low {
+        add("Inside Bar", "NEUTRAL", 0.5+0.5*(1-candleRange(cur)/candleRange(prev)))
+    }
+
+    // Outside bar - range engulfs the previous range, direction from the close
+    if cur.High > prev.High && cur.Low < prev.Low && candleRange(prev) > 0 {
+        confidence := 0.4 + clamp01(candleRange(cur)/candleRange(prev)-1)*0.4
+        if isBullish(cur) {
+            add("Bullish Outside Bar", "BULLISH", confidence)
+        } else if isBearish(cur) {
+            add("Bearish Outside Bar", "BEARISH", confidence)
+        }
+    }
+
+    if i < 2 {
+        return matches
+    }
+    first := klines[i-2]
+    middle := prev
+
+    // Morning star - long bearish, small body, long bullish closing into the first body
+    if isBearish(first) && body(first) >= avgBody && body(middle) <= avgBody*0.5 &&
+        isBullish(cur) && body(cur) >= avgBody*0.5 && cur.Close > bodyMid(first) {
+        add("Morning Star", "BULLISH", 0.6+clamp01((cur.Close-bodyMid(first))/body(first))*0.2+trendBonus(priorTrend(klines, i-1, 5), true))
+    }
+
+    // Evening star - long bullish, small body, long bearish closing into the first body
+    if isBullish(first) && body(first) >= avgBody && body(middle) <= avgBody*0.5 &&
+        isBearish(cur) && body(cur) >= avgBody*0.5 && cur.Close < bodyMid(first) {
+        add("Evening Star", "BEARISH", 0.6+clamp01((bodyMid(first)-cur.Close)/body(first))*0.2+trendBonus(priorTrend(klines, i-1, 5), false))
+    }
+
+    // Three white soldiers - three long bullish candles, each opening inside the
+    // previous body and closing near its high
+    soldiers := true
+    for j := i - 2; j <= i; j++ {
+        k := klines[j]
+        if !isBullish(k) || body(k) < avgBody*0.7 || upperShadow(k) > body(k)*0.3 {
+            soldiers = false
+            break
+        }
+        if j > i-2 {
+            p := klines[j-1]
+            if k.Open < p.Open || k.Open > p.Close || k.Close <= p.Close {
+                soldiers = false
+                break
+            }
+        }
+    }
+    if soldiers {
+        strength := (body(first) + body(middle) + body(cur)) / (3 * avgBody)
+        add("Three White Soldiers", "BULLISH", 0.6+clamp01(strength-1)*0.4)
+    }
+
+    return matches
+}
+
+// FilterPatterns returns the matches in the given direction with at least minConfidence
+func FilterPatterns(matches []PatternMatch, direction string, minConfidence float64) []PatternMatch {
+    filtered := []PatternMatch{}
+    for _, m := range matches {
+        if m.Direction == direction && m.Confidence >= minConfidence {
+            filtered = append(filtered, m)
+        }
+    }
+    return filtered
+}
+
+// PatternNames joins the pattern descriptions for display
+func PatternNames(matches []PatternMatch) string {
+    names := make([]string, len(matches))
+    for i, m := range matches {
+        names[i] = m.String()
+    }
+    return strings.Join(names, ", ")
+}
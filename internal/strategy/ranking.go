@@ -0,0 +1,341 @@
+// File: internal/strategy/ranking.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/internal/binance"
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "log"
+    "math"
+    "sort"
+    "strings"
+)
+
+// DefaultRankingFormula is the original hot coin score
+const DefaultRankingFormula = "price_change * 2 + quote_volume / 1000000"
+
+// CoinRank holds the relative strength metrics and final score of one coin
+type CoinRank struct {
+    Ticker     types.Ticker
+    RS24h      float64         // 24h performance relative to BTC (%)
+    RS         map[int]float64 // Window (bars) -> performance relative to BTC (%)
+    RSScore    float64         // Mean of the window RS values
+    Beta       float64         // Rolling beta of bar returns vs BTC
+    Alpha      float64         // Longest window return minus beta * BTC return (%)
+    Percentile float64         // Cross-sectional percentile of RSScore (0-100)
+    Score      float64         // Ranking formula result
+}
+
+// Ranker scores hot coins with a configurable formula that can use relative
+// strength vs BTCUSDT, rolling beta and cross-sectional percentile
+type Ranker struct {
+    client        *binance.Client
+    formula       *Expr
+    timeframe     string
+    windows       []int
+    betaBars      int
+    maxCandidates int
+    needsKlines   bool
+    bars          int
+}
+
+// rankingVars lists the variables available to the ranking formula
+func rankingVars(windows []int) map[string]bool {
+    vars := map[string]bool{
+        "price": true, "price_change": true, "quote_volume": true, "volume": true,
+        "btc_change": true, "rs_24h": true, "rs": true, "beta": true, "alpha": true,
+        "percentile": true, "rvol": true,
+    }
+    for _, w := range windows {
+        vars[fmt.Sprintf("rs_%d", w)] = true
+    }
+    return vars
+}
+
+// NewRanker compiles the ranking formula from config
+func NewRanker(config *types.Config, client *binance.Client) (*Ranker, error) {
+    cfg := config.Strategy.Ranking
+    r := &Ranker{
+        client:        client,
+        timeframe:     cfg.Timeframe,
+        windows:       cfg.Windows,
+        betaBars:      cfg.BetaBars,
+        maxCandidates: cfg.MaxCandidates,
+    }
+    if r.timeframe == "" {
+        r.timeframe = "1h"
+    }
+    if !validTimeframes[r.timeframe] {
+        return nil, fmt.Errorf("ranking timeframe %q is not supported", r.timeframe)
+    }
+    if len(r.windows) == 0 {
+        r.windows = []int{4, 24, 72}
+    }
+    if r.betaBars <= 0 {
+        r.betaBars = 72
+    }
+    if r.maxCandidates <= 0 {
+        r.maxCandidates = 30
+    }
+
+    source := cfg.Formula
+    if source == "" {
+        source = DefaultRankingFormula
+    }
+    formula, err := CompileExpr(source, rankingVars(r.windows))
+    if err != nil {
+        return nil, fmt.Errorf("ranking formula: %v", err)
+    }
+    r.formula = formula
+
+    // Klines are only fetched when the formula needs more than ticker data
+    r.bars = r.betaBars + 1
+    for _, w := range r.windows {
+        if w <= 0 || w >= 1000 {
+            return nil, fmt.Errorf("ranking window %d must be between 1 and 999 bars", w)
+        }
+        if w+1 > r.bars {
+            r.bars = w + 1
+        }
+        r.needsKlines = r.needsKlines || formula.Uses(fmt.Sprintf("rs_%d", w))
+    }
+    for _, name := range []string{"rs", "beta", "alpha", "percentile"} {
+        r.needsKlines = r.needsKlines || formula.Uses(name)
+    }
+    for tf, bars := range formula.Timeframes() {
+        if tf != r.timeframe {
+            return nil, fmt.Errorf("ranking formula may only use %q klines, found %q", r.timeframe, tf)
+        }
+        r.needsKlines = true
+        if bars > r.bars {
+            r.bars = bars
+        }
+    }
+    if r.bars > 1000 {
+        return nil, fmt.Errorf("ranking needs %d bars, Binance returns at most 1000", r.bars)
+    }
+
+    return r, nil
+}
+
+// relativePerformance returns how much coinChange beat btcChange, both in percent
+func relativePerformance(coinChange, btcChange float64) float64 {
+    return ((1+coinChange/100)/(1+btcChange/100) - 1) * 100
+}
+
+// barReturns returns simple close-to-close returns keyed by kline open time
+func barReturns(klines []types.Kline) map[int64]float64 {
+    returns := make(map[int64]float64, len(klines))
+    for i := 1; i < len(klines); i++ {
+        if klines[i-1].Close > 0 {
+            returns[klines[i].OpenTime.Unix()] = klines[i].Close/klines[i-1].Close - 1
+        }
+    }
+    return returns
+}
+
+// windowChange returns the percent change over the last bars klines
+func windowChange(klines []types.Kline, bars int) (float64, bool) {
+    if len(klines) <= bars || klines[len(klines)-1-bars].Close == 0 {
+        return 0, false
+    }
+    start := klines[len(klines)-1-bars].Close
+    return (klines[len(klines)-1].Close - start) / start * 100, true
+}
+
+// CalculateBeta - Beta of coin bar returns vs benchmark bar returns over the
+// last bars klines, matching bars by open time
+func CalculateBeta(coin, benchmark []types.Kline, bars int) float64 {
+    if len(coin) > bars+1 {
+        coin = coin[len(coin)-bars-1:]
+    }
+    benchReturns := barReturns(benchmark)
+
+    xs, ys := []float64{}, []float64{}
+    for t, r := range barReturns(coin) {
+        if b, ok := benchReturns[t]; ok {
+            xs = append(xs, b)
+            ys = append(ys, r)
+        }
+    }
+    if len(xs) < 2 {
+        return 1.0
+    }
+
+    meanX, meanY := CalculateSMA(xs, len(xs)), CalculateSMA(ys, len(ys))
+    covariance, variance := 0.0, 0.0
+    for i := range xs {
+        covariance += (xs[i] - meanX) * (ys[i] - meanY)
+        variance += (xs[i] - meanX) * (xs[i] - meanX)
+    }
+    if variance == 0 {
+        return 1.0
+    }
+    return covariance / variance
+}
+
+// percentiles returns the share of values strictly below each value (0-100)
+func percentiles(values []float64) []float64 {
+    result := make([]float64, len(values))
+    if len(values) < 2 {
+        for i := range result {
+            result[i] = 100
+        }
+        return result
+    }
+    for i, v := range values {
+        below := 0
+        for _, other := range values {
+            if other < v {
+                below++
+            }
+        }
+        result[i] = float64(below) / float64(len(values)-1) * 100
+    }
+    return result
+}
+
+// rankEnv evaluates the ranking formula for one coin
+type rankEnv struct {
+    vars   map[string]float64
+    klines []types.Kline
+    tf     string
+}
+
+func (e *rankEnv) Var(name string) (ExprValue, bool) {
+    v, ok := e.vars[name]
+    return numValue(v), ok
+}
+
+func (e *rankEnv) Klines(timeframe string) []types.Kline {
+    if timeframe != e.tf {
+        return nil
+    }
+    return e.klines
+}
+
+// Rank scores the candidates against the BTCUSDT ticker and returns them
+// sorted by score, highest first
+func (r *Ranker) Rank(candidates []types.Ticker, btc types.Ticker) []CoinRank {
+    ranks := make([]CoinRank, len(candidates))
+    for i, t := range candidates {
+        ranks[i] = CoinRank{
+            Ticker: t,
+            RS24h:  relativePerformance(t.PriceChangePercent, btc.PriceChangePercent),
+            RS:     make(map[int]float64),
+            Beta:   1.0,
+        }
+    }
+
+    // Pre-select by 24h RS so kline requests stay bounded
+    sort.SliceStable(ranks, func(i, j int) bool { return ranks[i].RS24h > ranks[j].RS24h })
+    if r.needsKlines && len(ranks) > r.maxCandidates {
+        ranks = ranks[:r.maxCandidates]
+    }
+
+    klines := make([][]types.Kline, len(ranks))
+    if r.needsKlines {
+        btcKlines, err := r.client.GetKlines("BTCUSDT", r.timeframe, r.bars)
+        if err != nil || len(btcKlines) == 0 {
+            log.Printf("⚠️  Ranking: failed to get BTCUSDT %s klines: %v", r.timeframe, err)
+        } else {
+            longest := r.windows[0]
+            for _, w := range r.windows {
+                if w > longest {
+                    longest = w
+                }
+            }
+            btcLongest, _ := windowChange(btcKlines, longest)
+
+            for i := range ranks {
+                coinKlines, err := r.client.GetKlines(ranks[i].Ticker.Symbol, r.timeframe, r.bars)
+                if err != nil {
+                    log.Printf("⚠️  Ranking: failed to get %s klines: %v", ranks[i].Ticker.Symbol, err)
+                    continue
+                }
+                klines[i] = coinKlines
+                r.measure(&ranks[i], coinKlines, btcKlines)
+                if change, ok := windowChange(coinKlines, longest); ok {
+                    ranks[i].Alpha = change - ranks[i].Beta*btcLongest
+                }
+            }
+        }
+    }
+
+    scores := make([]float64, len(ranks))
+    for i := range ranks {
+        scores[i] = ranks[i].RSScore
+    }
+    for i, p := range percentiles(scores) {
+        ranks[i].Percentile = p
+    }
+
+    for i := range ranks {
+        env := &rankEnv{vars: r.vars(ranks[i], btc), klines: klines[i], tf: r.timeframe}
+        value, err := r.formula.Eval(env)
+        if err != nil || value.IsStr || math.IsNaN(value.Num) {
+            log.Printf("⚠️  Ranking: %s scored 0 (%v)", ranks[i].Ticker.Symbol, err)
+            continue
+        }
+        ranks[i].Score = value.Num
+    }
+
+    sort.SliceStable(ranks, func(i, j int) bool { return ranks[i].Score > ranks[j].Score })
+    return ranks
+}
+
+// measure fills the window RS values and beta for one coin
+func (r *Ranker) measure(rank *CoinRank, coin, btc []types.Kline) {
+    sum, count := 0.0, 0
+    for _, w := range r.windows {
+        coinChange, ok := windowChange(coin, w)
+        btcChange, btcOK := windowChange(btc, w)
+        if !ok || !btcOK {
+            continue // Not enough history, e.g. a recent listing
+        }
+        rank.RS[w] = relativePerformance(coinChange, btcChange)
+        sum += rank.RS[w]
+        count++
+    }
+    if count > 0 {
+        rank.RSScore = sum / float64(count)
+    }
+    rank.Beta = CalculateBeta(coin, btc, r.betaBars)
+}
+
+func (r *Ranker) vars(rank CoinRank, btc types.Ticker) map[string]float64 {
+    vars := map[string]float64{
+        "price":        rank.Ticker.LastPrice,
+        "price_change": rank.Ticker.PriceChangePercent,
+        "quote_volume": NotionalVolume(rank.Ticker),
+        "volume":       rank.Ticker.Volume,
+        "btc_change":   btc.PriceChangePercent,
+        "rs_24h":       rank.RS24h,
+        "rs":           rank.RSScore,
+        "beta":         rank.Beta,
+        "alpha":        rank.Alpha,
+        "percentile":   rank.Percentile,
+        "rvol":         rank.Ticker.RVOL,
+    }
+    for _, w := range r.windows {
+        vars[fmt.Sprintf("rs_%d", w)] = rank.RS[w]
+    }
+    return vars
+}
+
+// Describe formats the ranking metrics for logs
+func (c CoinRank) Describe() string {
+    parts := []string{fmt.Sprintf("score %.2f", c.Score), fmt.Sprintf("RS24h %+.2f%%", c.RS24h)}
+    windows := make([]int, 0, len(c.RS))
+    for w := range c.RS {
+        windows = append(windows, w)
+    }
+    sort.Ints(windows)
+    for _, w := range windows {
+        parts = append(parts, fmt.Sprintf("RS%d %+.2f%%", w, c.RS[w]))
+    }
+    parts = append(parts, fmt.Sprintf("beta %.2f", c.Beta), fmt.Sprintf("alpha %+.2f%%", c.Alpha),
+        fmt.Sprintf("pct %.0f", c.Percentile))
+    return strings.Join(parts, " | ")
+}
@@ -0,0 +1,841 @@
+// File: internal/strategy/rules.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "log"
+    "math"
+    "sort"
+    "strings"
+    "time"
+)
+
+// Rule types understood by the engine
+const (
+    RuleRequired = "required" // Signal is rejected if the criterion fails
+    RuleScored   = "scored"   // Weight is added to the score if the criterion passes
+    RuleVeto     = "veto"     // Signal is rejected if the criterion passes
+)
+
+// SignalContext holds every value computed for a symbol during GenerateSignal,
+// so criteria can be evaluated without recomputing indicators
+type SignalContext struct {
+    Config  *types.Config
+    Ticker  types.Ticker
+    Prices  []float64 // 1m closes
+    Volumes []float64 // 1m volumes
+    Klines5m []types.Kline // 5m klines
+
+    RSI           float64
+    SMA20         float64
+    EMA12         float64
+    EMA26         float64
+    MACD          float64
+    MACDSignal    float64
+    MACDHistogram float64
+    UpperBB       float64
+    MiddleBB      float64
+    LowerBB       float64
+
+    VolumeSpike    bool
+    VolumeRatio    float64
+    RVOL           float64 // Time-of-day relative volume, 0 when disabled
+    VolumeProfile  string
+    VolumeStrength float64
+
+    Regime           string
+    RegimeConfidence float64
+    ATR              float64
+
+    MTFScore    float64
+    MTFAnalyses []types.TimeframeAnalysis
+
+    Patterns []PatternMatch // Candlestick patterns on the last closed 5m kline
+
+    Market  *types.MarketState   // Market-wide state for this cycle, may be nil
+    Anomaly *types.AnomalyReport // Pump-and-dump / wash-trading check, may be nil
+
+    Timeframes map[string][]types.Kline // Klines fetched for expressions
+    Position   *types.Position          // Set when evaluating exits
+}
+
+// signalVars are the variables expressions can read from a SignalContext
+var signalVars = map[string]func(ctx *SignalContext) (ExprValue, bool){
+    "price":             func(c *SignalContext) (ExprValue, bool) { return numValue(c.Ticker.LastPrice), true },
+    "close":             func(c *SignalContext) (ExprValue, bool) { return numValue(c.Ticker.LastPrice), true },
+    "price_change":      func(c *SignalContext) (ExprValue, bool) { return numValue(c.Ticker.PriceChangePercent), true },
+    "quote_volume":      func(c *SignalContext) (ExprValue, bool) { return numValue(NotionalVolume(c.Ticker)), true },
+    "volume":            func(c *SignalContext) (ExprValue, bool) { return numValue(c.Ticker.Volume), true },
+    "volume_ratio":      func(c *SignalContext) (ExprValue, bool) { return numValue(c.VolumeRatio), true },
+    "rvol": func(c *SignalContext) (ExprValue, bool) {
+        if c.RVOL <= 0 {
+            return ExprValue{}, false
+        }
+        return numValue(c.RVOL), true
+    },
+    "volume_profile":    func(c *SignalContext) (ExprValue, bool) { return ExprValue{Str: c.VolumeProfile, IsStr: true}, true },
+    "rsi":               func(c *SignalContext) (ExprValue, bool) { return numValue(c.RSI), true },
+    "sma20":             func(c *SignalContext) (ExprValue, bool) { return numValue(c.SMA20), true },
+    "ema12":             func(c *SignalContext) (ExprValue, bool) { return numValue(c.EMA12), true },
+    "ema26":             func(c *SignalContext) (ExprValue, bool) { return numValue(c.EMA26), true },
+    "macd":              func(c *SignalContext) (ExprValue, bool) { return numValue(c.MACD), true },
+    "macd_signal":       func(c *SignalContext) (ExprValue, bool) { return numValue(c.MACDSignal), true },
+    "macd_hist":         func(c *SignalContext) (ExprValue, bool) { return numValue(c.MACDHistogram), true },
+    "bb_upper":          func(c *SignalContext) (ExprValue, bool) { return numValue(c.UpperBB), true },
+    "bb_middle":         func(c *SignalContext) (ExprValue, bool) { return numValue(c.MiddleBB), true },
+    "bb_lower":          func(c *SignalContext) (ExprValue, bool) { return numValue(c.LowerBB), true },
+    "atr":               func(c *SignalContext) (ExprValue, bool) { return numValue(c.ATR), true },
+    "mtf_score":         func(c *SignalContext) (ExprValue, bool) { return numValue(c.MTFScore), true },
+    "regime":            func(c *SignalContext) (ExprValue, bool) { return ExprValue{Str: c.Regime, IsStr: true}, true },
+    "regime_confidence": func(c *SignalContext) (ExprValue, bool) { return numValue(c.RegimeConfidence), true },
+    "btc_trend": func(c *SignalContext) (ExprValue, bool) {
+        if c.Market == nil {
+            return ExprValue{}, false
+        }
+        return ExprValue{Str: c.Market.BTCTrend, IsStr: true}, true
+    },
+    "btc_strength":         marketVar(func(m *types.MarketState) float64 { return m.BTCStrength }),
+    "btc_change":           marketVar(func(m *types.MarketState) float64 { return m.BTCChange }),
+    "market_ad_ratio":      marketVar(func(m *types.MarketState) float64 { return m.AdvanceDecline }),
+    "market_above_vwap":    marketVar(func(m *types.MarketState) float64 { return m.AboveVWAP }),
+    "market_volume_change": marketVar(func(m *types.MarketState) float64 { return m.VolumeChange }),
+    "market_risk_off": marketVar(func(m *types.MarketState) float64 {
+        if m.RiskOff {
+            return 1
+        }
+        return 0
+    }),
+    "anomaly_flags": func(c *SignalContext) (ExprValue, bool) {
+        if c.Anomaly == nil {
+            return ExprValue{}, false
+        }
+        return numValue(float64(len(c.Anomaly.Flags))), true
+    },
+    "pnl_percent": func(c *SignalContext) (ExprValue, bool) {
+        if c.Position == nil {
+            return ExprValue{}, false
+        }
+        return numValue(c.Position.PnLPercent), true
+    },
+    "hold_minutes": func(c *SignalContext) (ExprValue, bool) {
+        if c.Position == nil || c.Position.EntryTime.IsZero() {
+            return ExprValue{}, false
+        }
+        return numValue(time.Since(c.Position.EntryTime).Minutes()), true
+    },
+}
+
+// marketVar reads a numeric market state field, unavailable before the first update
+func marketVar(field func(m *types.MarketState) float64) func(c *SignalContext) (ExprValue, bool) {
+    return func(c *SignalContext) (ExprValue, bool) {
+        if c.Market == nil {
+            return ExprValue{}, false
+        }
+        return numValue(field(c.Market)), true
+    }
+}
+
+// exitOnlyVars describe an open position and are only set for exit expressions
+var exitOnlyVars = map[string]bool{"pnl_percent": true, "hold_minutes": true}
+
+// SignalVarNames is the set of variables allowed in entry and veto expressions
+func SignalVarNames() map[string]bool {
+    names := make(map[string]bool, len(signalVars))
+    for name := range signalVars {
+        if !exitOnlyVars[name] {
+            names[name] = true
+        }
+    }
+    return names
+}
+
+// ExitVarNames is the set of variables allowed in exit expressions
+func ExitVarNames() map[string]bool {
+    names := make(map[string]bool, len(signalVars))
+    for name := range signalVars {
+        names[name] = true
+    }
+    return names
+}
+
+// Var implements ExprEnv
+func (ctx *SignalContext) Var(name string) (ExprValue, bool) {
+    if fn, ok := signalVars[name]; ok {
+        return fn(ctx)
+    }
+    return ExprValue{}, false
+}
+
+// Klines implements ExprEnv
+func (ctx *SignalContext) Klines(timeframe string) []types.Kline {
+    return ctx.Timeframes[timeframe]
+}
+
+// CriterionResult is the outcome of a single criterion
+type CriterionResult struct {
+    Passed    bool
+    Value     float64 // Raw value that was tested
+    Threshold float64 // Threshold it was tested against
+    Detail    string  // Human readable description
+}
+
+type criterionFunc func(ctx *SignalContext, params map[string]float64) CriterionResult
+
+// criteria is the registry of every criterion that can be referenced from config
+var criteria = map[string]criterionFunc{
+    "momentum":           criterionMomentum,
+    "volume":             criterionVolume,
+    "volume_spike":       criterionVolumeSpike,
+    "volume_profile":     criterionVolumeProfile,
+    "rsi_range":          criterionRSIRange,
+    "rsi_extreme":        criterionRSIExtreme,
+    "above_sma":          criterionAboveSMA,
+    "ema_crossover":      criterionEMACrossover,
+    "macd_bullish":       criterionMACDBullish,
+    "macd_positive":      criterionMACDPositive,
+    "bb_position":        criterionBBPosition,
+    "mtf":                criterionMTF,
+    "regime_favorable":   criterionRegimeFavorable,
+    "regime_volatile":    criterionRegimeIs("VOLATILE"),
+    "regime_ranging":     criterionRegimeIs("RANGING"),
+    "adx_trend":          criterionADXTrend,
+    "supertrend":         criterionSuperTrend,
+    "ichimoku":           criterionIchimoku,
+    "obv_rising":         criterionOBVRising,
+    "mfi_range":          criterionMFIRange,
+    "keltner":            criterionKeltner,
+    "cci":                criterionCCI,
+    "williams_r":         criterionWilliamsR,
+    "psar":               criterionPSAR,
+    "bullish_pattern":    criterionPattern("BULLISH"),
+    "bearish_pattern":    criterionPattern("BEARISH"),
+    "bullish_divergence": criterionDivergence("BULLISH"),
+    "bearish_divergence": criterionDivergence("BEARISH"),
+    "market_risk_on":     criterionMarketRiskOn,
+    "anomaly":            criterionAnomaly,
+}
+
+// param returns a rule parameter or its default
+func param(params map[string]float64, key string, def float64) float64 {
+    if v, ok := params[key]; ok {
+        return v
+    }
+    return def
+}
+
+func criterionMomentum(ctx *SignalContext, params map[string]float64) CriterionResult {
+    min := param(params, "min_percent", ctx.Config.Strategy.MinPriceChange)
+    change := ctx.Ticker.PriceChangePercent
+    if change >= min {
+        return CriterionResult{true, change, min, fmt.Sprintf("+%.1f%% momentum", change)}
+    }
+    return CriterionResult{false, change, min, fmt.Sprintf("weak momentum (%.2f%%)", change)}
+}
+
+func criterionVolume(ctx *SignalContext, params map[string]float64) CriterionResult {
+    // min_volume is in the notional asset; min_usdt is its older name
+    min := param(params, "min_volume", param(params, "min_usdt", ctx.Config.Strategy.MinVolume))
+    volume := NotionalVolume(ctx.Ticker)
+    if volume >= min {
+        return CriterionResult{true, volume, min, fmt.Sprintf("$%.0f volume", volume)}
+    }
+    return CriterionResult{false, volume, min, fmt.Sprintf("low volume ($%.0f)", volume)}
+}
+
+func criterionVolumeSpike(ctx *SignalContext, params map[string]float64) CriterionResult {
+    multiplier := ctx.Config.Strategy.VolumeSpikeMultiplier
+    if multiplier <= 0 {
+        multiplier = 1.5
+    }
+    multiplier = param(params, "multiplier", multiplier)
+    
+    // Judge against what is normal for this hour when RVOL is available
+    if ctx.RVOL > 0 {
+        if ctx.RVOL >= multiplier {
+            return CriterionResult{true, ctx.RVOL, multiplier, fmt.Sprintf("%.1fx RVOL spike", ctx.RVOL)}
+        }
+        return CriterionResult{false, ctx.RVOL, multiplier, fmt.Sprintf("no RVOL spike (%.1fx)", ctx.RVOL)}
+    }
+    if ctx.VolumeRatio >= multiplier {
+        return CriterionResult{true, ctx.VolumeRatio, multiplier, fmt.Sprintf("%.1fx volume spike", ctx.VolumeRatio)}
+    }
+    return CriterionResult{false, ctx.VolumeRatio, multiplier, fmt.Sprintf("no volume spike (%.1fx)", ctx.VolumeRatio)}
+}
+
+func criterionVolumeProfile(ctx *SignalContext, params map[string]float64) CriterionResult {
+    min := param(params, "min_strength", 0)
+    passed := ctx.VolumeProfile == "ACCUMULATION" && ctx.VolumeStrength >= min
+    if passed {
+        return CriterionResult{true, ctx.VolumeStrength, min, "accumulation phase"}
+    }
+    return CriterionResult{false, ctx.VolumeStrength, min, fmt.Sprintf("no accumulation (%s)", ctx.VolumeProfile)}
+}
+
+func criterionRSIRange(ctx *SignalContext, params map[string]float64) CriterionResult {
+    minDefault, maxDefault := ctx.Config.Strategy.MinRSIEntry, ctx.Config.Strategy.MaxRSIEntry
+    if minDefault == 0 && maxDefault == 0 {
+        minDefault, maxDefault = 40, 75
+    }
+    min := param(params, "min", minDefault)
+    max := param(params, "max", maxDefault)
+    if ctx.RSI >= min && ctx.RSI <= max {
+        return CriterionResult{true, ctx.RSI, min, fmt.Sprintf("RSI in range (%.1f)", ctx.RSI)}
+    }
+    return CriterionResult{false, ctx.RSI, min, fmt.Sprintf("poor RSI (%.1f)", ctx.RSI)}
+}
+
+func criterionRSIExtreme(ctx *SignalContext, params map[string]float64) CriterionResult {
+    low := param(params, "low", 5)
+    high := param(params, "high", 95)
+    if ctx.RSI <= low || ctx.RSI >= high {
+        return CriterionResult{true, ctx.RSI, high, fmt.Sprintf("extreme RSI (%.1f)", ctx.RSI)}
+    }
+    return CriterionResult{false, ctx.RSI, high, fmt.Sprintf("RSI not extreme (%.1f)", ctx.RSI)}
+}
+
+func criterionAboveSMA(ctx *SignalContext, params map[string]float64) CriterionResult {
+    period := int(param(params, "period", 20))
+    tolerance := param(params, "tolerance_percent", 2.0)
+    sma := ctx.SMA20
+    if period != 20 {
+        sma = CalculateSMA(ctx.Prices, period)
+    }
+    threshold := sma * (1 - tolerance/100)
+    if ctx.Ticker.LastPrice > threshold {
+        return CriterionResult{true, ctx.Ticker.LastPrice, threshold, fmt.Sprintf("above SMA%d", period)}
+    }
+    return CriterionResult{false, ctx.Ticker.LastPrice, threshold, fmt.Sprintf("below SMA%d", period)}
+}
+
+func criterionEMACrossover(ctx *SignalContext, params map[string]float64) CriterionResult {
+    fast := int(param(params, "fast", 12))
+    slow := int(param(params, "slow", 26))
+    fastEMA, slowEMA := ctx.EMA12, ctx.EMA26
+    if fast != 12 || slow != 26 {
+        fastEMA = CalculateEMA(ctx.Prices, fast)
+        slowEMA = CalculateEMA(ctx.Prices, slow)
+    }
+    if fastEMA > slowEMA {
+        return CriterionResult{true, fastEMA, slowEMA, "bullish EMA crossover"}
+    }
+    return CriterionResult{false, fastEMA, slowEMA, "bearish EMA alignment"}
+}
+
+func criterionMACDBullish(ctx *SignalContext, params map[string]float64) CriterionResult {
+    requireHistogram := param(params, "require_histogram", 0) > 0
+    passed := ctx.MACD > ctx.MACDSignal
+    if requireHistogram {
+        passed = passed && ctx.MACDHistogram > 0
+    }
+    if passed {
+        return CriterionResult{true, ctx.MACD, ctx.MACDSignal, "MACD bullish"}
+    }
+    return CriterionResult{false, ctx.MACD, ctx.MACDSignal, "MACD not bullish"}
+}
+
+func criterionMACDPositive(ctx *SignalContext, params map[string]float64) CriterionResult {
+    if ctx.MACDHistogram > 0 {
+        return CriterionResult{true, ctx.MACDHistogram, 0, "MACD histogram positive"}
+    }
+    return CriterionResult{false, ctx.MACDHistogram, 0, fmt.Sprintf("MACD histogram negative (%.4f)", ctx.MACDHistogram)}
+}
+
+func criterionBBPosition(ctx *SignalContext, params map[string]float64) CriterionResult {
+    price := ctx.Ticker.LastPrice
+    if price > ctx.LowerBB && price < ctx.UpperBB && price > ctx.MiddleBB {
+        return CriterionResult{true, price, ctx.MiddleBB, "upper half of BB"}
+    }
+    return CriterionResult{false, price, ctx.MiddleBB, "outside upper half of BB"}
+}
+
+func criterionMTF(ctx *SignalContext, params map[string]float64) CriterionResult {
+    min := param(params, "min_score", 0.50)
+    if ctx.MTFScore > min {
+        return CriterionResult{true, ctx.MTFScore, min, fmt.Sprintf("MTF bullish (%.2f)", ctx.MTFScore)}
+    }
+    return CriterionResult{false, ctx.MTFScore, min, fmt.Sprintf("MTF bearish (%.2f)", ctx.MTFScore)}
+}
+
+func criterionRegimeFavorable(ctx *SignalContext, params map[string]float64) CriterionResult {
+    min := param(params, "min_confidence", 0.6)
+    favorable := ctx.Regime == "TRENDING" || ctx.Regime == "TRANSITIONING"
+    if favorable && ctx.RegimeConfidence > min {
+        return CriterionResult{true, ctx.RegimeConfidence, min, fmt.Sprintf("%s regime", ctx.Regime)}
+    }
+    return CriterionResult{false, ctx.RegimeConfidence, min, fmt.Sprintf("unfavorable regime (%s)", ctx.Regime)}
+}
+
+func criterionRegimeIs(regime string) criterionFunc {
+    return func(ctx *SignalContext, params map[string]float64) CriterionResult {
+        if ctx.Regime == regime {
+            return CriterionResult{true, ctx.RegimeConfidence, 0, fmt.Sprintf("%s market", strings.ToLower(regime))}
+        }
+        return CriterionResult{false, ctx.RegimeConfidence, 0, fmt.Sprintf("not %s", strings.ToLower(regime))}
+    }
+}
+
+// Criteria below are computed on the 5m klines
+
+func criterionADXTrend(ctx *SignalContext, params map[string]float64) CriterionResult {
+    min := param(params, "min_adx", 25)
+    adx, plusDI, minusDI := CalculateADX(ctx.Klines5m, int(param(params, "period", 14)))
+    if adx >= min && plusDI > minusDI {
+        return CriterionResult{true, adx, min, fmt.Sprintf("ADX uptrend (%.1f)", adx)}
+    }
+    return CriterionResult{false, adx, min, fmt.Sprintf("no ADX uptrend (%.1f, +DI %.1f/-DI %.1f)", adx, plusDI, minusDI)}
+}
+
+func criterionSuperTrend(ctx *SignalContext, params map[string]float64) CriterionResult {
+    line, bullish := CalculateSuperTrend(ctx.Klines5m, int(param(params, "period", 10)), param(params, "multiplier", 3))
+    if line > 0 && bullish {
+        return CriterionResult{true, ctx.Ticker.LastPrice, line, "SuperTrend bullish"}
+    }
+    return CriterionResult{false, ctx.Ticker.LastPrice, line, "SuperTrend bearish"}
+}
+
+func criterionIchimoku(ctx *SignalContext, params map[string]float64) CriterionResult {
+    cloud := CalculateIchimoku(ctx.Klines5m)
+    requireCross := param(params, "require_tk_cross", 0) > 0
+    passed := cloud.AboveCloud(ctx.Ticker.LastPrice)
+    if requireCross {
+        passed = passed && cloud.Tenkan > cloud.Kijun
+    }
+    top := math.Max(cloud.SpanA, cloud.SpanB)
+    if passed {
+        return CriterionResult{true, ctx.Ticker.LastPrice, top, "above Ichimoku cloud"}
+    }
+    return CriterionResult{false, ctx.Ticker.LastPrice, top, "not above Ichimoku cloud"}
+}
+
+func criterionOBVRising(ctx *SignalContext, params map[string]float64) CriterionResult {
+    slope := Slope(OBVSeries(ctx.Klines5m), int(param(params, "lookback", 10)))
+    if slope > 0 {
+        return CriterionResult{true, slope, 0, "OBV rising"}
+    }
+    return CriterionResult{false, slope, 0, "OBV not rising"}
+}
+
+func criterionMFIRange(ctx *SignalContext, params map[string]float64) CriterionResult {
+    min := param(params, "min", 50)
+    max := param(params, "max", 80)
+    mfi := CalculateMFI(ctx.Klines5m, int(param(params, "period", 14)))
+    if mfi >= min && mfi <= max {
+        return CriterionResult{true, mfi, min, fmt.Sprintf("MFI in range (%.1f)", mfi)}
+    }
+    return CriterionResult{false, mfi, min, fmt.Sprintf("MFI out of range (%.1f)", mfi)}
+}
+
+func criterionKeltner(ctx *SignalContext, params map[string]float64) CriterionResult {
+    upper, middle, _ := CalculateKeltnerChannels(ctx.Klines5m, int(param(params, "period", 20)), param(params, "multiplier", 2))
+    threshold := middle
+    if param(params, "breakout", 0) > 0 {
+        threshold = upper
+    }
+    if threshold > 0 && ctx.Ticker.LastPrice > threshold {
+        return CriterionResult{true, ctx.Ticker.LastPrice, threshold, "above Keltner threshold"}
+    }
+    return CriterionResult{false, ctx.Ticker.LastPrice, threshold, "below Keltner threshold"}
+}
+
+func criterionCCI(ctx *SignalContext, params map[string]float64) CriterionResult {
+    min := param(params, "min", 0)
+    max := param(params, "max", 200)
+    cci := CalculateCCI(ctx.Klines5m, int(param(params, "period", 20)))
+    if cci >= min && cci <= max {
+        return CriterionResult{true, cci, min, fmt.Sprintf("CCI bullish (%.0f)", cci)}
+    }
+    return CriterionResult{false, cci, min, fmt.Sprintf("CCI out of range (%.0f)", cci)}
+}
+
+func criterionWilliamsR(ctx *SignalContext, params map[string]float64) CriterionResult {
+    min := param(params, "min", -80)
+    max := param(params, "max", -20)
+    wr := CalculateWilliamsR(ctx.Klines5m, int(param(params, "period", 14)))
+    if wr >= min && wr <= max {
+        return CriterionResult{true, wr, min, fmt.Sprintf("Williams %%R in range (%.0f)", wr)}
+    }
+    return CriterionResult{false, wr, min, fmt.Sprintf("Williams %%R out of range (%.0f)", wr)}
+}
+
+func criterionPSAR(ctx *SignalContext, params map[string]float64) CriterionResult {
+    sar, bullish := CalculateParabolicSAR(ctx.Klines5m, param(params, "step", 0.02), param(params, "max_step", 0.2))
+    if sar > 0 && bullish {
+        return CriterionResult{true, ctx.Ticker.LastPrice, sar, "Parabolic SAR below price"}
+    }
+    return CriterionResult{false, ctx.Ticker.LastPrice, sar, "Parabolic SAR above price"}
+}
+
+func criterionPattern(direction string) criterionFunc {
+    return func(ctx *SignalContext, params map[string]float64) CriterionResult {
+        min := param(params, "min_confidence", 0.6)
+        matches := FilterPatterns(ctx.Patterns, direction, min)
+        best := 0.0
+        for _, m := range matches {
+            best = math.Max(best, m.Confidence)
+        }
+        if len(matches) > 0 {
+            return CriterionResult{true, best, min, PatternNames(matches)}
+        }
+        return CriterionResult{false, best, min, fmt.Sprintf("no %s pattern", strings.ToLower(direction))}
+    }
+}
+
+// criterionDivergence passes when at least min_count divergences in the given
+// direction were found across the multi-timeframe analyses. Hidden divergences
+// only count with include_hidden = 1.
+func criterionDivergence(direction string) criterionFunc {
+    return func(ctx *SignalContext, params map[string]float64) CriterionResult {
+        minCount := param(params, "min_count", 1)
+        includeHidden := param(params, "include_hidden", 0) > 0
+        
+        found := []string{}
+        for _, analysis := range ctx.MTFAnalyses {
+            for _, d := range analysis.Divergences {
+                if d.Direction != direction || (d.Kind == "HIDDEN" && !includeHidden) {
+                    continue
+                }
+                found = append(found, DescribeDivergence(d))
+            }
+        }
+        
+        count := float64(len(found))
+        if count > 0 && count >= minCount {
+            return CriterionResult{true, count, minCount, strings.Join(found, ", ")}
+        }
+        return CriterionResult{false, count, minCount, fmt.Sprintf("no %s divergence", strings.ToLower(direction))}
+    }
+}
+
+func criterionMarketRiskOn(ctx *SignalContext, params map[string]float64) CriterionResult {
+    if ctx.Market == nil {
+        return CriterionResult{true, 0, 0, "market state unavailable"}
+    }
+    if ctx.Market.RiskOff {
+        return CriterionResult{false, 1, 0, "risk-off: " + strings.Join(ctx.Market.RiskOffReasons, ", ")}
+    }
+    return CriterionResult{true, 0, 0, fmt.Sprintf("market risk-on (A/D %.2f)", ctx.Market.AdvanceDecline)}
+}
+
+func criterionAnomaly(ctx *SignalContext, params map[string]float64) CriterionResult {
+    if ctx.Anomaly == nil {
+        return CriterionResult{false, 0, 0, "anomaly check disabled"}
+    }
+    flags := float64(len(ctx.Anomaly.Flags))
+    if ctx.Anomaly.Flagged {
+        return CriterionResult{true, flags, 0, "anomaly: " + strings.Join(ctx.Anomaly.Flags, ", ")}
+    }
+    return CriterionResult{false, flags, 0, "no trading anomalies"}
+}
+
+// DefaultEntryRules reproduces the original hardcoded scoring from the legacy config knobs
+func DefaultEntryRules(config *types.Config) []types.RuleConfig {
+    rules := []types.RuleConfig{
+        {Criterion: "rsi_extreme", Type: RuleVeto},
+        {Criterion: "momentum", Type: RuleScored, Weight: 15},
+        {Criterion: "volume", Type: RuleScored, Weight: 15},
+        {Criterion: "volume_spike", Type: RuleScored, Weight: 5},
+        {Criterion: "volume_profile", Type: RuleScored, Weight: 5},
+        {Criterion: "rsi_range", Type: RuleScored, Weight: 10},
+        {Criterion: "rsi_range", Name: "rsi_optimal", Type: RuleScored, Weight: 5,
+            Params: map[string]float64{"min": 45, "max": 65}},
+        {Criterion: "mtf", Type: RuleScored, Weight: 10},
+        {Criterion: "mtf", Name: "mtf_strong", Type: RuleScored, Weight: 10,
+            Params: map[string]float64{"min_score": 0.65}},
+        {Criterion: "above_sma", Type: RuleScored, Weight: 5},
+        {Criterion: "ema_crossover", Type: RuleScored, Weight: 5},
+        {Criterion: "macd_bullish", Type: RuleScored, Weight: 10,
+            Params: map[string]float64{"require_histogram": 1}},
+        {Criterion: "bb_position", Type: RuleScored, Weight: 5},
+        {Criterion: "regime_favorable", Type: RuleScored, Weight: 10},
+        {Criterion: "regime_volatile", Type: RuleScored, Weight: -5},
+        {Criterion: "regime_ranging", Type: RuleScored, Weight: -3},
+    }
+
+    if config.Strategy.RequireEMACrossover {
+        rules = append(rules, types.RuleConfig{Criterion: "ema_crossover", Name: "ema_crossover_required", Type: RuleRequired})
+    }
+    if config.Strategy.RequireMACDPositive {
+        rules = append(rules, types.RuleConfig{Criterion: "macd_positive", Type: RuleRequired})
+    }
+    if config.Strategy.RequireVolumeSpike {
+        rules = append(rules, types.RuleConfig{Criterion: "volume_spike", Name: "volume_spike_required", Type: RuleRequired})
+    }
+
+    return rules
+}
+
+// appendFeatureVetoes adds the veto for each enabled detector whose criterion the
+// rules don't already use, so turning a detector on always has an effect
+func appendFeatureVetoes(config *types.Config, rules []types.RuleConfig) []types.RuleConfig {
+    vetoes := []struct {
+        enabled   bool
+        criterion string
+    }{
+        {config.Strategy.Divergence.Enabled, "bearish_divergence"},
+        {config.Strategy.Anomaly.Enabled, "anomaly"},
+    }
+
+    for _, veto := range vetoes {
+        if !veto.enabled || hasCriterion(rules, veto.criterion) {
+            continue
+        }
+        rules = append(rules, types.RuleConfig{Criterion: veto.criterion, Type: RuleVeto})
+    }
+    return rules
+}
+
+func hasCriterion(rules []types.RuleConfig, criterion string) bool {
+    for _, rule := range rules {
+        if rule.Criterion == criterion {
+            return true
+        }
+    }
+    return false
+}
+
+// DefaultRegimeThresholds are the original per-regime score thresholds
+func DefaultRegimeThresholds() map[string]float64 {
+    return map[string]float64{
+        "VOLATILE": 0.75, // Require much higher score in volatile markets
+        "TRENDING": 0.55, // Can be slightly more aggressive in trending markets
+        "RANGING":  0.70, // Need strong signal in ranging markets
+    }
+}
+
+// RuleOutcome records how a single rule evaluated
+type RuleOutcome struct {
+    Rule   types.RuleConfig
+    Result CriterionResult
+    Points float64
+}
+
+// RuleEvaluation is the aggregate result of running every rule
+type RuleEvaluation struct {
+    Outcomes     []RuleOutcome
+    Score        float64
+    MaxScore     float64
+    Strength     float64
+    Rejected     bool
+    RejectReason string
+}
+
+// RuleEngine evaluates the configured entry rules against a SignalContext
+type RuleEngine struct {
+    rules            []types.RuleConfig
+    baseThreshold    float64
+    regimeThresholds map[string]float64
+    entryExprs       []*Expr
+    vetoExprs        []*Expr
+    exitExprs        []*Expr
+    timeframes       map[string]int
+}
+
+// NewRuleEngine validates the configured rules and builds the engine
+func NewRuleEngine(config *types.Config) (*RuleEngine, error) {
+    var err error
+    // Copy so the defaults filled in below don't leak back into the config
+    rules := append([]types.RuleConfig(nil), config.Strategy.EntryRules...)
+    if len(rules) == 0 {
+        rules = DefaultEntryRules(config)
+    }
+    rules = appendFeatureVetoes(config, rules)
+
+    for i := range rules {
+        rule := &rules[i]
+        if _, ok := criteria[rule.Criterion]; !ok {
+            return nil, fmt.Errorf("entry rule %d: unknown criterion %q (available: %s)",
+                i+1, rule.Criterion, strings.Join(CriterionNames(), ", "))
+        }
+        if rule.Name == "" {
+            rule.Name = rule.Criterion
+        }
+        if rule.Type == "" {
+            rule.Type = RuleScored
+        }
+        switch rule.Type {
+        case RuleRequired, RuleScored, RuleVeto:
+        default:
+            return nil, fmt.Errorf("entry rule %q: unknown type %q (expected required, scored or veto)",
+                rule.Name, rule.Type)
+        }
+        if rule.Type == RuleScored && rule.Weight == 0 {
+            return nil, fmt.Errorf("entry rule %q: scored rules need a non-zero weight", rule.Name)
+        }
+    }
+
+    baseThreshold := config.Strategy.MinSignalStrength
+    if baseThreshold <= 0 {
+        baseThreshold = 0.60
+    }
+
+    regimeThresholds := config.Strategy.RegimeThresholds
+    if regimeThresholds == nil {
+        regimeThresholds = DefaultRegimeThresholds()
+    }
+    for regime, threshold := range regimeThresholds {
+        if threshold <= 0 || threshold > 1 {
+            return nil, fmt.Errorf("regime threshold for %s must be in (0, 1], got %.2f", regime, threshold)
+        }
+    }
+
+    engine := &RuleEngine{
+        rules:            rules,
+        baseThreshold:    baseThreshold,
+        regimeThresholds: regimeThresholds,
+        timeframes:       make(map[string]int),
+    }
+    
+    exprs := config.Strategy.Expressions
+    if engine.entryExprs, err = engine.compileAll("entry", exprs.Entry, SignalVarNames()); err != nil {
+        return nil, err
+    }
+    if engine.vetoExprs, err = engine.compileAll("veto", exprs.Veto, SignalVarNames()); err != nil {
+        return nil, err
+    }
+    if engine.exitExprs, err = engine.compileAll("exit", exprs.Exit, ExitVarNames()); err != nil {
+        return nil, err
+    }
+    
+    return engine, nil
+}
+
+func (e *RuleEngine) compileAll(kind string, sources []string, vars map[string]bool) ([]*Expr, error) {
+    compiled := make([]*Expr, 0, len(sources))
+    for _, source := range sources {
+        expr, err := CompileExpr(source, vars)
+        if err != nil {
+            return nil, fmt.Errorf("%s %v", kind, err)
+        }
+        for tf, bars := range expr.Timeframes() {
+            if bars > e.timeframes[tf] {
+                e.timeframes[tf] = bars
+            }
+        }
+        compiled = append(compiled, expr)
+    }
+    return compiled, nil
+}
+
+// Timeframes returns the kline timeframes (and bar counts) that the configured
+// expressions need fetched before evaluation
+func (e *RuleEngine) Timeframes() map[string]int {
+    return e.timeframes
+}
+
+// EvaluateExit reports whether any exit expression holds for the context
+func (e *RuleEngine) EvaluateExit(ctx *SignalContext) (bool, string) {
+    for _, expr := range e.exitExprs {
+        hit, err := expr.EvalBool(ctx)
+        if err != nil {
+            log.Printf("   ⚠️  Exit expression error: %v", err)
+            continue
+        }
+        if hit {
+            return true, fmt.Sprintf("exit condition met: %s", expr.Source)
+        }
+    }
+    return false, ""
+}
+
+// HasExitConditions reports whether any exit expressions are configured
+func (e *RuleEngine) HasExitConditions() bool {
+    return len(e.exitExprs) > 0
+}
+
+// CriterionNames lists every registered criterion
+func CriterionNames() []string {
+    names := make([]string, 0, len(criteria))
+    for name := range criteria {
+        names = append(names, name)
+    }
+    sort.Strings(names)
+    return names
+}
+
+// Threshold returns the minimum strength required in the given regime
+func (e *RuleEngine) Threshold(regime string) float64 {
+    if threshold, ok := e.regimeThresholds[regime]; ok {
+        return threshold
+    }
+    return e.baseThreshold
+}
+
+// Evaluate runs every rule and computes the normalized strength
+func (e *RuleEngine) Evaluate(ctx *SignalContext) RuleEvaluation {
+    eval := RuleEvaluation{}
+
+    for _, rule := range e.rules {
+        result := criteria[rule.Criterion](ctx, rule.Params)
+        outcome := RuleOutcome{Rule: rule, Result: result}
+
+        switch rule.Type {
+        case RuleRequired:
+            if !result.Passed && !eval.Rejected {
+                eval.Rejected = true
+                eval.RejectReason = fmt.Sprintf("required %s failed: %s", rule.Name, result.Detail)
+            }
+        case RuleVeto:
+            if result.Passed && !eval.Rejected {
+                eval.Rejected = true
+                eval.RejectReason = fmt.Sprintf("vetoed by %s: %s", rule.Name, result.Detail)
+            }
+        case RuleScored:
+            if result.Passed {
+                outcome.Points = rule.Weight
+                eval.Score += rule.Weight
+            }
+            // Negative weights are penalties and don't raise the maximum
+            if rule.Weight > 0 {
+                eval.MaxScore += rule.Weight
+            }
+        }
+
+        eval.Outcomes = append(eval.Outcomes, outcome)
+    }
+
+    // Custom expressions act as additional required and veto rules
+    for _, expr := range e.entryExprs {
+        hit, err := expr.EvalBool(ctx)
+        detail := expr.Source
+        if err != nil {
+            detail = err.Error()
+        }
+        eval.Outcomes = append(eval.Outcomes, RuleOutcome{
+            Rule:   types.RuleConfig{Criterion: "expression", Name: expr.Source, Type: RuleRequired},
+            Result: CriterionResult{Passed: hit, Detail: detail},
+        })
+        if !hit && !eval.Rejected {
+            eval.Rejected = true
+            eval.RejectReason = fmt.Sprintf("entry condition not met: %s", detail)
+        }
+    }
+    for _, expr := range e.vetoExprs {
+        hit, err := expr.EvalBool(ctx)
+        detail := expr.Source
+        if err != nil {
+            // A veto that can't be checked (e.g. klines failed to load) blocks the entry
+            log.Printf("   ⚠️  Veto expression error: %v", err)
+            detail = fmt.Sprintf("%s (could not evaluate: %v)", expr.Source, err)
+            hit = true
+        }
+        eval.Outcomes = append(eval.Outcomes, RuleOutcome{
+            Rule:   types.RuleConfig{Criterion: "expression", Name: expr.Source, Type: RuleVeto},
+            Result: CriterionResult{Passed: hit, Detail: detail},
+        })
+        if hit && !eval.Rejected {
+            eval.Rejected = true
+            eval.RejectReason = fmt.Sprintf("vetoed by condition: %s", detail)
+        }
+    }
+
+    if eval.MaxScore > 0 {
+        eval.Strength = eval.Score / eval.MaxScore
+    }
+    if eval.Strength < 0 {
+        eval.Strength = 0
+    }
+
+    return eval
+}
@@ -0,0 +1,155 @@
+// File: internal/strategy/rules_test.go
+// ============================================
+package strategy
+
+import (
+    "strings"
+    "testing"
+
+    "binance-trading-bot/pkg/types"
+)
+
+func TestExitOnlyVariablesRejectedOutsideExits(t *testing.T) {
+    tests := []struct {
+        name    string
+        entry   []string
+        veto    []string
+        exit    []string
+        wantErr bool
+    }{
+        {name: "pnl in entry", entry: []string{"pnl_percent > 0"}, wantErr: true},
+        {name: "hold in veto", veto: []string{"hold_minutes > 30"}, wantErr: true},
+        {name: "both in exit", exit: []string{"pnl_percent < -2 || hold_minutes > 120"}},
+        {name: "signal vars in entry", entry: []string{"rsi < 70"}, veto: []string{"btc_change < -3"}},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            config := &types.Config{}
+            config.Strategy.Expressions.Entry = tt.entry
+            config.Strategy.Expressions.Veto = tt.veto
+            config.Strategy.Expressions.Exit = tt.exit
+
+            _, err := NewRuleEngine(config)
+            if tt.wantErr && err == nil {
+                t.Fatal("expected a compile error")
+            }
+            if !tt.wantErr && err != nil {
+                t.Fatalf("unexpected error: %v", err)
+            }
+        })
+    }
+}
+
+func TestVetoEvaluationErrorRejects(t *testing.T) {
+    config := &types.Config{}
+    config.Strategy.Expressions.Veto = []string{"btc_change < -3"}
+    engine, err := NewRuleEngine(config)
+    if err != nil {
+        t.Fatalf("NewRuleEngine: %v", err)
+    }
+
+    // No market state: btc_change can't be read, so the veto must not fail open
+    eval := engine.Evaluate(&SignalContext{Config: config})
+    if !eval.Rejected {
+        t.Fatal("entry passed although the veto could not be evaluated")
+    }
+    var outcome *RuleOutcome
+    for i := range eval.Outcomes {
+        if eval.Outcomes[i].Rule.Criterion == "expression" {
+            outcome = &eval.Outcomes[i]
+        }
+    }
+    if outcome == nil {
+        t.Fatal("no outcome recorded for the veto expression")
+    }
+    if !outcome.Result.Passed || !strings.Contains(outcome.Result.Detail, "could not evaluate") {
+        t.Errorf("veto outcome = %+v, want a hit with the evaluation error", outcome.Result)
+    }
+}
+
+func TestNewRuleEngineLeavesConfigRulesUntouched(t *testing.T) {
+    config := &types.Config{}
+    config.Strategy.EntryRules = []types.RuleConfig{{Criterion: "volume_spike", Weight: 1}}
+
+    if _, err := NewRuleEngine(config); err != nil {
+        t.Fatalf("NewRuleEngine: %v", err)
+    }
+    rule := config.Strategy.EntryRules[0]
+    if rule.Name != "" || rule.Type != "" {
+        t.Errorf("config rule was normalized in place: %+v", rule)
+    }
+}
+
+func TestFeatureVetoesAddedToCustomRules(t *testing.T) {
+    tests := []struct {
+        name  string
+        rules []types.RuleConfig
+        want  map[string]int
+    }{
+        {
+            name:  "custom rules get both vetoes",
+            rules: []types.RuleConfig{{Criterion: "momentum", Weight: 10}},
+            want:  map[string]int{"bearish_divergence": 1, "anomaly": 1},
+        },
+        {
+            name: "listed criterion is not duplicated",
+            rules: []types.RuleConfig{
+                {Criterion: "momentum", Weight: 10},
+                {Criterion: "anomaly", Type: RuleScored, Weight: -20},
+            },
+            want: map[string]int{"bearish_divergence": 1, "anomaly": 1},
+        },
+        {
+            name: "default rules",
+            want: map[string]int{"bearish_divergence": 1, "anomaly": 1},
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            config := &types.Config{}
+            config.Strategy.EntryRules = tt.rules
+            config.Strategy.Divergence.Enabled = true
+            config.Strategy.Anomaly.Enabled = true
+
+            engine, err := NewRuleEngine(config)
+            if err != nil {
+                t.Fatalf("NewRuleEngine: %v", err)
+            }
+            got := make(map[string]int)
+            for _, rule := range engine.rules {
+                got[rule.Criterion]++
+            }
+            for criterion, count := range tt.want {
+                if got[criterion] != count {
+                    t.Errorf("%s rules = %d, want %d", criterion, got[criterion], count)
+                }
+            }
+        })
+    }
+}
+
+func TestCriterionVolumeParams(t *testing.T) {
+    config := &types.Config{}
+    config.Strategy.MinVolume = 5000
+    ctx := &SignalContext{Config: config, Ticker: types.Ticker{Symbol: "ABCUSDT", QuoteVolume: 2000}}
+
+    tests := []struct {
+        name   string
+        params map[string]float64
+        want   bool
+    }{
+        {name: "config default", want: false},
+        {name: "min_volume", params: map[string]float64{"min_volume": 1000}, want: true},
+        {name: "min_usdt alias", params: map[string]float64{"min_usdt": 1000}, want: true},
+        {name: "min_volume wins", params: map[string]float64{"min_volume": 3000, "min_usdt": 1000}, want: false},
+    }
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            if got := criterionVolume(ctx, tt.params); got.Passed != tt.want {
+                t.Errorf("passed = %v, want %v (%s)", got.Passed, tt.want, got.Detail)
+            }
+        })
+    }
+}
@@ -0,0 +1,158 @@
+// File: internal/strategy/rvol.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/internal/binance"
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "sync"
+    "time"
+)
+
+// VolumeBaseline is a symbol's average volume for each UTC hour of the day
+type VolumeBaseline struct {
+    Hourly  [24]float64
+    Days    int // Days of history behind the averages
+    Updated time.Time
+}
+
+// BuildVolumeBaseline averages closed 1h klines by UTC hour of day. Hours
+// without samples fall back to the overall hourly mean.
+func BuildVolumeBaseline(klines []types.Kline, now time.Time) VolumeBaseline {
+    baseline := VolumeBaseline{Updated: now}
+    var sums [24]float64
+    var counts [24]int
+    total, samples := 0.0, 0
+
+    for _, k := range klines {
+        if !k.CloseTime.Before(now) {
+            continue // Still forming
+        }
+        hour := k.OpenTime.UTC().Hour()
+        sums[hour] += k.Volume
+        counts[hour]++
+        total += k.Volume
+        samples++
+    }
+    if samples == 0 {
+        return baseline
+    }
+
+    mean := total / float64(samples)
+    for hour := range baseline.Hourly {
+        if counts[hour] > 0 {
+            baseline.Hourly[hour] = sums[hour] / float64(counts[hour])
+        } else {
+            baseline.Hourly[hour] = mean
+        }
+    }
+    baseline.Days = (samples + 23) / 24
+    return baseline
+}
+
+// Expected returns the normal volume for the hour ending at now, blending the
+// previous and current hour-of-day averages by how far into the hour now is
+func (b VolumeBaseline) Expected(now time.Time) float64 {
+    now = now.UTC()
+    current := now.Hour()
+    previous := (current + 23) % 24
+    f := float64(now.Minute()*60+now.Second()) / 3600
+    return b.Hourly[previous]*(1-f) + b.Hourly[current]*f
+}
+
+// CalculateRVOL - volume of the last hour of 1m klines relative to what is
+// normal for that time of day. Returns 0 without a baseline.
+func CalculateRVOL(klines []types.Kline, baseline VolumeBaseline, now time.Time) float64 {
+    expected := baseline.Expected(now)
+    if expected <= 0 {
+        return 0
+    }
+    since := now.Add(-time.Hour)
+    volume := 0.0
+    for _, k := range klines {
+        if k.OpenTime.After(since) {
+            volume += k.Volume
+        }
+    }
+    return volume / expected
+}
+
+// RVOLScanner keeps time-of-day volume baselines per symbol and measures RVOL.
+// Safe for concurrent use by the analysis workers.
+type RVOLScanner struct {
+    client  *binance.Client
+    days    int
+    refresh time.Duration
+
+    mu        sync.Mutex
+    baselines map[string]VolumeBaseline
+}
+
+// NewRVOLScanner creates the scanner from config
+func NewRVOLScanner(config *types.Config, client *binance.Client) (*RVOLScanner, error) {
+    cfg := config.Strategy.RVOL
+    r := &RVOLScanner{
+        client:    client,
+        days:      cfg.Days,
+        refresh:   time.Duration(cfg.RefreshMinutes) * time.Minute,
+        baselines: map[string]VolumeBaseline{},
+    }
+    if r.days <= 0 {
+        r.days = 14
+    }
+    if r.days*24+1 > 1000 {
+        return nil, fmt.Errorf("rvol days %d needs more than 1000 hourly klines (max 41)", r.days)
+    }
+    if r.refresh <= 0 {
+        r.refresh = 6 * time.Hour
+    }
+    return r, nil
+}
+
+// Baseline returns the cached baseline of a symbol, rebuilding it from 1h
+// klines when it is older than the refresh interval
+func (r *RVOLScanner) Baseline(symbol string) (VolumeBaseline, error) {
+    r.mu.Lock()
+    baseline, ok := r.baselines[symbol]
+    r.mu.Unlock()
+    if ok && time.Since(baseline.Updated) < r.refresh {
+        return baseline, nil
+    }
+
+    klines, err := r.client.GetKlines(symbol, "1h", r.days*24+1)
+    if err != nil {
+        return VolumeBaseline{}, fmt.Errorf("failed to get 1h klines: %v", err)
+    }
+    baseline = BuildVolumeBaseline(klines, time.Now())
+
+    r.mu.Lock()
+    r.baselines[symbol] = baseline
+    r.mu.Unlock()
+    return baseline, nil
+}
+
+// RVOL returns a symbol's volume over the last hour relative to its
+// time-of-day baseline
+func (r *RVOLScanner) RVOL(symbol string) (float64, error) {
+    baseline, err := r.Baseline(symbol)
+    if err != nil {
+        return 0, err
+    }
+    klines, err := r.client.GetKlines(symbol, "1m", 61)
+    if err != nil {
+        return 0, fmt.Errorf("failed to get 1m klines: %v", err)
+    }
+    return CalculateRVOL(klines, baseline, time.Now()), nil
+}
+
+// Prune drops the baselines of symbols not measured for a day
+func (r *RVOLScanner) Prune() {
+    r.mu.Lock()
+    defer r.mu.Unlock()
+    for symbol, baseline := range r.baselines {
+        if time.Since(baseline.Updated) > 24*time.Hour {
+            delete(r.baselines, symbol)
+        }
+    }
+}
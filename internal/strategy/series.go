@@ -0,0 +1,253 @@
+// File: internal/strategy/series.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "math"
+)
+
+// Series variants of the indicators. Every series has the same length as its
+// input and holds NaN for bars inside the indicator's warm-up period, so index
+// i always lines up with klines[i]. Values after the warm-up match what the
+// single-value Calculate* function returns for the prefix ending at i.
+
+func nanSeries(n int) []float64 {
+    series := make([]float64, n)
+    for i := range series {
+        series[i] = math.NaN()
+    }
+    return series
+}
+
+// ClosePrices extracts closing prices from klines
+func ClosePrices(klines []types.Kline) []float64 {
+    closes := make([]float64, len(klines))
+    for i, k := range klines {
+        closes[i] = k.Close
+    }
+    return closes
+}
+
+// Volumes extracts base asset volumes from klines
+func Volumes(klines []types.Kline) []float64 {
+    volumes := make([]float64, len(klines))
+    for i, k := range klines {
+        volumes[i] = k.Volume
+    }
+    return volumes
+}
+
+// SMASeries - Simple Moving Average for every bar (NaN for the first period-1)
+func SMASeries(prices []float64, period int) []float64 {
+    series := nanSeries(len(prices))
+    for i := period - 1; i < len(prices) && period > 0; i++ {
+        series[i] = CalculateSMA(prices[:i+1], period)
+    }
+    return series
+}
+
+// EMASeries - Exponential Moving Average for every bar (NaN for the first period-1)
+func EMASeries(prices []float64, period int) []float64 {
+    series := nanSeries(len(prices))
+    ema := NewEMA(period)
+    for i, p := range prices {
+        ema.Update(p)
+        if ema.Ready() {
+            series[i] = ema.Value()
+        }
+    }
+    return series
+}
+
+// RSISeries - RSI for every bar (NaN for the first period bars)
+func RSISeries(prices []float64, period int) []float64 {
+    series := nanSeries(len(prices))
+    rsi := NewRSI(period)
+    for i, p := range prices {
+        rsi.Update(p)
+        if rsi.Ready() {
+            series[i] = rsi.Value()
+        }
+    }
+    return series
+}
+
+// MACDSeries - MACD line, signal line and histogram for every bar. The MACD
+// line starts at bar 25, signal and histogram once nine MACD values exist.
+func MACDSeries(prices []float64) (macd, signal, histogram []float64) {
+    macd = nanSeries(len(prices))
+    signal = nanSeries(len(prices))
+    histogram = nanSeries(len(prices))
+
+    m := NewMACD()
+    for i, p := range prices {
+        m.Update(p)
+        if !m.Ready() {
+            continue
+        }
+        macd[i], signal[i], histogram[i] = m.Value()
+        if !m.signal.Ready() {
+            signal[i] = math.NaN()
+            histogram[i] = math.NaN()
+        }
+    }
+    return macd, signal, histogram
+}
+
+// BollingerSeries - Bollinger Bands for every bar (NaN for the first period-1)
+func BollingerSeries(prices []float64, period int, stdDev float64) (upper, middle, lower []float64) {
+    upper = nanSeries(len(prices))
+    middle = nanSeries(len(prices))
+    lower = nanSeries(len(prices))
+
+    bb := NewBollinger(period, stdDev)
+    for i, p := range prices {
+        bb.Update(p)
+        if bb.Ready() {
+            upper[i], middle[i], lower[i] = bb.Value()
+        }
+    }
+    return upper, middle, lower
+}
+
+// ATRSeries - Average True Range for every bar (NaN for the first period bars)
+func ATRSeries(klines []types.Kline, period int) []float64 {
+    series := nanSeries(len(klines))
+    atr := NewATR(period)
+    for i, k := range klines {
+        atr.Update(k)
+        if atr.Ready() {
+            series[i] = atr.Value()
+        }
+    }
+    return series
+}
+
+// StochasticSeries - Stochastic %K and %D for every bar (NaN for the first period-1)
+func StochasticSeries(klines []types.Kline, period int) (k, d []float64) {
+    k = nanSeries(len(klines))
+    d = nanSeries(len(klines))
+
+    stoch := NewStochastic(period)
+    for i, kline := range klines {
+        stoch.Update(kline)
+        if stoch.Ready() {
+            k[i], d[i] = stoch.Value()
+        }
+    }
+    return k, d
+}
+
+// VWAPSeries - Cumulative VWAP from the first kline to every bar
+func VWAPSeries(klines []types.Kline) []float64 {
+    series := nanSeries(len(klines))
+    vwap := NewVWAP()
+    for i, k := range klines {
+        vwap.Update(k)
+        if v := vwap.Value(); v != 0 {
+            series[i] = v
+        }
+    }
+    return series
+}
+
+// ============================================
+// Series helpers
+// ============================================
+
+// CrossAboveAt reports whether a crossed above b on bar i
+func CrossAboveAt(a, b []float64, i int) bool {
+    if i < 1 || i >= len(a) || i >= len(b) {
+        return false
+    }
+    if math.IsNaN(a[i-1]) || math.IsNaN(b[i-1]) || math.IsNaN(a[i]) || math.IsNaN(b[i]) {
+        return false
+    }
+    return a[i-1] <= b[i-1] && a[i] > b[i]
+}
+
+// CrossBelowAt reports whether a crossed below b on bar i
+func CrossBelowAt(a, b []float64, i int) bool {
+    return CrossAboveAt(b, a, i)
+}
+
+// CrossAbove reports whether a crossed above b on the latest bar
+func CrossAbove(a, b []float64) bool {
+    return CrossAboveAt(a, b, len(a)-1)
+}
+
+// CrossBelow reports whether a crossed below b on the latest bar
+func CrossBelow(a, b []float64) bool {
+    return CrossBelowAt(a, b, len(a)-1)
+}
+
+// CrossAboveSeries marks every bar where a crossed above b
+func CrossAboveSeries(a, b []float64) []bool {
+    crosses := make([]bool, len(a))
+    for i := range crosses {
+        crosses[i] = CrossAboveAt(a, b, i)
+    }
+    return crosses
+}
+
+// CrossBelowSeries marks every bar where a crossed below b
+func CrossBelowSeries(a, b []float64) []bool {
+    return CrossAboveSeries(b, a)
+}
+
+// Level returns a constant series, handy for crossing a fixed threshold
+func Level(value float64, n int) []float64 {
+    series := make([]float64, n)
+    for i := range series {
+        series[i] = value
+    }
+    return series
+}
+
+// Slope - Least squares slope per bar over the last lookback values.
+// Returns NaN if the window contains warm-up values.
+func Slope(series []float64, lookback int) float64 {
+    if lookback < 2 || len(series) < lookback {
+        return math.NaN()
+    }
+
+    window := series[len(series)-lookback:]
+    sumX, sumY, sumXY, sumXX := 0.0, 0.0, 0.0, 0.0
+    for i, y := range window {
+        if math.IsNaN(y) {
+            return math.NaN()
+        }
+        x := float64(i)
+        sumX += x
+        sumY += y
+        sumXY += x * y
+        sumXX += x * x
+    }
+
+    n := float64(lookback)
+    denominator := n*sumXX - sumX*sumX
+    if denominator == 0 {
+        return 0
+    }
+    return (n*sumXY - sumX*sumY) / denominator
+}
+
+// BarsSince returns how many bars ago the condition was last true
+// (0 = latest bar), or -1 if it never was
+func BarsSince(conditions []bool) int {
+    for i := len(conditions) - 1; i >= 0; i-- {
+        if conditions[i] {
+            return len(conditions) - 1 - i
+        }
+    }
+    return -1
+}
+
+// Last returns the latest value of a series, or NaN if it is empty
+func Last(series []float64) float64 {
+    if len(series) == 0 {
+        return math.NaN()
+    }
+    return series[len(series)-1]
+}
@@ -0,0 +1,468 @@
+// File: internal/strategy/streaming.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/pkg/types"
+    "math"
+)
+
+// Stateful indicators that update in O(1) per new price or kline (window based
+// indicators scan their fixed window, which is constant in the history length).
+// Every indicator can be snapshotted and restored, and the batch functions in
+// indicators.go are thin wrappers around them so both give identical values.
+
+// WindowState is a fixed size ring buffer of the most recent values
+type WindowState struct {
+    Values []float64
+    Next   int // Index the next value is written to
+    Count  int // Number of values written so far (capped at len(Values))
+}
+
+func newWindow(size int) WindowState {
+    if size < 1 {
+        size = 1
+    }
+    return WindowState{Values: make([]float64, size)}
+}
+
+func (w *WindowState) push(v float64) {
+    w.Values[w.Next] = v
+    w.Next = (w.Next + 1) % len(w.Values)
+    if w.Count < len(w.Values) {
+        w.Count++
+    }
+}
+
+func (w *WindowState) full() bool {
+    return w.Count == len(w.Values)
+}
+
+// each visits the window oldest first
+func (w *WindowState) each(fn func(v float64)) {
+    start := 0
+    if w.full() {
+        start = w.Next
+    }
+    for i := 0; i < w.Count; i++ {
+        fn(w.Values[(start+i)%len(w.Values)])
+    }
+}
+
+func (w WindowState) clone() WindowState {
+    values := make([]float64, len(w.Values))
+    copy(values, w.Values)
+    w.Values = values
+    return w
+}
+
+// ============================================
+// EMA
+// ============================================
+
+type EMAState struct {
+    Period int
+    Count  int
+    Sum    float64 // Sum of the first Period values, used to seed with an SMA
+    Value  float64
+}
+
+type EMA struct {
+    state EMAState
+}
+
+func NewEMA(period int) *EMA {
+    return &EMA{state: EMAState{Period: period}}
+}
+
+func (e *EMA) Update(price float64) float64 {
+    s := &e.state
+    s.Count++
+    if s.Count <= s.Period {
+        s.Sum += price
+        if s.Count == s.Period {
+            s.Value = s.Sum / float64(s.Period)
+        }
+        return e.Value()
+    }
+    multiplier := 2.0 / float64(s.Period+1)
+    s.Value = (price-s.Value)*multiplier + s.Value
+    return s.Value
+}
+
+func (e *EMA) Ready() bool {
+    return e.state.Period > 0 && e.state.Count >= e.state.Period
+}
+
+// Value returns the EMA, or 0 until Period prices have been seen
+func (e *EMA) Value() float64 {
+    if !e.Ready() {
+        return 0
+    }
+    return e.state.Value
+}
+
+func (e *EMA) Snapshot() EMAState    { return e.state }
+func (e *EMA) Restore(state EMAState) { e.state = state }
+
+// ============================================
+// RSI (Wilder smoothing)
+// ============================================
+
+type RSIState struct {
+    Period  int
+    Count   int // Prices seen
+    Prev    float64
+    AvgGain float64
+    AvgLoss float64
+}
+
+type RSI struct {
+    state RSIState
+}
+
+func NewRSI(period int) *RSI {
+    return &RSI{state: RSIState{Period: period}}
+}
+
+func (r *RSI) Update(price float64) float64 {
+    s := &r.state
+    s.Count++
+    if s.Count == 1 {
+        s.Prev = price
+        return r.Value()
+    }
+
+    change := price - s.Prev
+    s.Prev = price
+    gain, loss := 0.0, 0.0
+    if change > 0 {
+        gain = change
+    } else {
+        loss = math.Abs(change)
+    }
+
+    changes := s.Count - 1
+    if changes <= s.Period {
+        // First RS calculation uses SMA
+        s.AvgGain += gain
+        s.AvgLoss += loss
+        if changes == s.Period {
+            s.AvgGain /= float64(s.Period)
+            s.AvgLoss /= float64(s.Period)
+        }
+    } else {
+        // Subsequent calculations use smoothed averages (Wilder's smoothing)
+        s.AvgGain = (s.AvgGain*float64(s.Period-1) + gain) / float64(s.Period)
+        s.AvgLoss = (s.AvgLoss*float64(s.Period-1) + loss) / float64(s.Period)
+    }
+    return r.Value()
+}
+
+func (r *RSI) Ready() bool {
+    return r.state.Period > 0 && r.state.Count >= r.state.Period+1
+}
+
+// Value returns the RSI, or the neutral 50 until Period+1 prices have been seen
+func (r *RSI) Value() float64 {
+    if !r.Ready() {
+        return 50.0
+    }
+    s := r.state
+
+    // Handle edge cases
+    if s.AvgLoss == 0 {
+        if s.AvgGain == 0 {
+            return 50.0 // No movement
+        }
+        return 100.0 // All gains, no losses
+    }
+
+    rs := s.AvgGain / s.AvgLoss
+    rsi := 100.0 - (100.0 / (1.0 + rs))
+
+    // Safety bounds
+    if rsi < 0 {
+        rsi = 0
+    }
+    if rsi > 100 {
+        rsi = 100
+    }
+    return rsi
+}
+
+func (r *RSI) Snapshot() RSIState    { return r.state }
+func (r *RSI) Restore(state RSIState) { r.state = state }
+
+// ============================================
+// MACD (12, 26, 9)
+// ============================================
+
+type MACDState struct {
+    Count  int
+    Fast   EMAState
+    Slow   EMAState
+    Signal EMAState
+}
+
+type MACD struct {
+    count  int
+    fast   *EMA
+    slow   *EMA
+    signal *EMA
+}
+
+func NewMACD() *MACD {
+    return &MACD{fast: NewEMA(12), slow: NewEMA(26), signal: NewEMA(9)}
+}
+
+func (m *MACD) Update(price float64) (macd, signal, histogram float64) {
+    m.count++
+    m.fast.Update(price)
+    m.slow.Update(price)
+
+    // The signal line starts from the 27th price, matching the original batch
+    // calculation which collected MACD values from index 26 onwards
+    if m.count > 26 {
+        m.signal.Update(m.fast.Value() - m.slow.Value())
+    }
+    return m.Value()
+}
+
+func (m *MACD) Ready() bool {
+    return m.count >= 26
+}
+
+// Value returns MACD, signal and histogram; signal and histogram stay 0 until
+// nine MACD values have been collected
+func (m *MACD) Value() (macd, signal, histogram float64) {
+    if !m.Ready() {
+        return 0, 0, 0
+    }
+    macd = m.fast.Value() - m.slow.Value()
+    if m.signal.Ready() {
+        signal = m.signal.Value()
+        histogram = macd - signal
+    }
+    return macd, signal, histogram
+}
+
+func (m *MACD) Snapshot() MACDState {
+    return MACDState{Count: m.count, Fast: m.fast.Snapshot(), Slow: m.slow.Snapshot(), Signal: m.signal.Snapshot()}
+}
+
+func (m *MACD) Restore(state MACDState) {
+    m.count = state.Count
+    m.fast.Restore(state.Fast)
+    m.slow.Restore(state.Slow)
+    m.signal.Restore(state.Signal)
+}
+
+// ============================================
+// Bollinger Bands
+// ============================================
+
+type BollingerState struct {
+    StdDev float64
+    Window WindowState
+}
+
+type Bollinger struct {
+    state BollingerState
+}
+
+func NewBollinger(period int, stdDev float64) *Bollinger {
+    return &Bollinger{state: BollingerState{StdDev: stdDev, Window: newWindow(period)}}
+}
+
+func (b *Bollinger) Update(price float64) (upper, middle, lower float64) {
+    b.state.Window.push(price)
+    return b.Value()
+}
+
+func (b *Bollinger) Ready() bool {
+    return b.state.Window.full()
+}
+
+func (b *Bollinger) Value() (upper, middle, lower float64) {
+    if !b.Ready() {
+        return 0, 0, 0
+    }
+    w := &b.state.Window
+    period := float64(len(w.Values))
+
+    sum := 0.0
+    w.each(func(v float64) { sum += v })
+    middle = sum / period
+
+    // Calculate standard deviation
+    variance := 0.0
+    w.each(func(v float64) { variance += math.Pow(v-middle, 2) })
+    variance = variance / period
+    stdDeviation := math.Sqrt(variance)
+
+    upper = middle + (b.state.StdDev * stdDeviation)
+    lower = middle - (b.state.StdDev * stdDeviation)
+    return upper, middle, lower
+}
+
+func (b *Bollinger) Snapshot() BollingerState {
+    state := b.state
+    state.Window = b.state.Window.clone()
+    return state
+}
+
+func (b *Bollinger) Restore(state BollingerState) {
+    b.state = state
+    b.state.Window = state.Window.clone()
+}
+
+// ============================================
+// ATR (simple average of true ranges)
+// ============================================
+
+type ATRState struct {
+    Count     int // Klines seen
+    PrevClose float64
+    Ranges    WindowState
+}
+
+type ATR struct {
+    state ATRState
+}
+
+func NewATR(period int) *ATR {
+    return &ATR{state: ATRState{Ranges: newWindow(period)}}
+}
+
+func (a *ATR) Update(k types.Kline) float64 {
+    s := &a.state
+    s.Count++
+    if s.Count > 1 {
+        highLow := k.High - k.Low
+        highClose := math.Abs(k.High - s.PrevClose)
+        lowClose := math.Abs(k.Low - s.PrevClose)
+        s.Ranges.push(math.Max(highLow, math.Max(highClose, lowClose)))
+    }
+    s.PrevClose = k.Close
+    return a.Value()
+}
+
+func (a *ATR) Ready() bool {
+    return a.state.Ranges.full()
+}
+
+// Value returns the ATR, or 0 until Period+1 klines have been seen
+func (a *ATR) Value() float64 {
+    if !a.Ready() {
+        return 0
+    }
+    sum := 0.0
+    a.state.Ranges.each(func(v float64) { sum += v })
+    return sum / float64(len(a.state.Ranges.Values))
+}
+
+func (a *ATR) Snapshot() ATRState {
+    state := a.state
+    state.Ranges = a.state.Ranges.clone()
+    return state
+}
+
+func (a *ATR) Restore(state ATRState) {
+    a.state = state
+    a.state.Ranges = state.Ranges.clone()
+}
+
+// ============================================
+// Stochastic
+// ============================================
+
+type StochasticState struct {
+    Close float64
+    Highs WindowState
+    Lows  WindowState
+}
+
+type Stochastic struct {
+    state StochasticState
+}
+
+func NewStochastic(period int) *Stochastic {
+    return &Stochastic{state: StochasticState{Highs: newWindow(period), Lows: newWindow(period)}}
+}
+
+func (st *Stochastic) Update(k types.Kline) (kValue, d float64) {
+    st.state.Highs.push(k.High)
+    st.state.Lows.push(k.Low)
+    st.state.Close = k.Close
+    return st.Value()
+}
+
+func (st *Stochastic) Ready() bool {
+    return st.state.Highs.full()
+}
+
+func (st *Stochastic) Value() (k, d float64) {
+    if !st.Ready() {
+        return 50, 50
+    }
+    high := math.Inf(-1)
+    low := math.Inf(1)
+    st.state.Highs.each(func(v float64) { high = math.Max(high, v) })
+    st.state.Lows.each(func(v float64) { low = math.Min(low, v) })
+
+    if high-low == 0 {
+        return 50, 50
+    }
+
+    k = ((st.state.Close - low) / (high - low)) * 100
+    d = k // Simplified - in production, calculate 3-period SMA of K
+    return k, d
+}
+
+func (st *Stochastic) Snapshot() StochasticState {
+    state := st.state
+    state.Highs = st.state.Highs.clone()
+    state.Lows = st.state.Lows.clone()
+    return state
+}
+
+func (st *Stochastic) Restore(state StochasticState) {
+    st.state = state
+    st.state.Highs = state.Highs.clone()
+    st.state.Lows = state.Lows.clone()
+}
+
+// ============================================
+// VWAP (cumulative)
+// ============================================
+
+type VWAPState struct {
+    TotalVolume float64
+    PriceVolume float64
+}
+
+type VWAP struct {
+    state VWAPState
+}
+
+func NewVWAP() *VWAP {
+    return &VWAP{}
+}
+
+func (v *VWAP) Update(k types.Kline) float64 {
+    typicalPrice := (k.High + k.Low + k.Close) / 3
+    v.state.TotalVolume += k.Volume
+    v.state.PriceVolume += typicalPrice * k.Volume
+    return v.Value()
+}
+
+func (v *VWAP) Value() float64 {
+    if v.state.TotalVolume == 0 {
+        return 0
+    }
+    return v.state.PriceVolume / v.state.TotalVolume
+}
+
+func (v *VWAP) Snapshot() VWAPState    { return v.state }
+func (v *VWAP) Restore(state VWAPState) { v.state = state }
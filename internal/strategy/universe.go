@@ -0,0 +1,386 @@
+// File: internal/strategy/universe.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/internal/binance"
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "log"
+    "regexp"
+    "sort"
+    "strings"
+    "time"
+)
+
+// DefaultStablecoins are the base assets excluded by universe.exclude_stablecoins
+var DefaultStablecoins = []string{
+    "USDT", "USDC", "FDUSD", "TUSD", "BUSD", "DAI", "USDP", "PAX", "USDD",
+    "PYUSD", "USDE", "USD1", "BFUSD", "UST", "EUR", "EURI", "AEUR",
+}
+
+// leveragedSuffixes mark Binance leveraged tokens (BTCUP, ETHDOWN, BNBBULL, ...)
+var leveragedSuffixes = []string{"UP", "DOWN", "BULL", "BEAR"}
+
+// Universe selects the pairs FindHotCoins may consider: configured quote
+// assets, allow/deny lists and regex excludes, with stablecoins and leveraged
+// tokens classified from exchangeInfo. Volumes are converted to a common
+// notional asset so pairs quoted in different assets compare.
+type Universe struct {
+    client      *binance.Client
+    quotes      []string
+    notional    string
+    whitelist   map[string]bool
+    blacklist   map[string]bool
+    excludes    []*regexp.Regexp
+    stablecoins map[string]bool
+    leveraged   bool // Exclude leveraged tokens
+    dedupeBases bool
+    refresh     time.Duration
+
+    symbols   map[string]types.SymbolInfo // From the last exchangeInfo refresh
+    bases     map[string]bool             // Base assets listed on the exchange
+    refreshed time.Time
+    rates     map[string]float64 // Notional rates from the last Filter call
+}
+
+// NewUniverse builds the universe filter from config
+func NewUniverse(config *types.Config, client *binance.Client) (*Universe, error) {
+    cfg := config.Strategy.Universe
+    u := &Universe{
+        client:      client,
+        notional:    strings.ToUpper(cfg.NotionalAsset),
+        whitelist:   upperSet(cfg.Whitelist),
+        blacklist:   upperSet(cfg.Blacklist),
+        stablecoins: map[string]bool{},
+        leveraged:   cfg.ExcludeLeveraged,
+        dedupeBases: cfg.DedupeBaseAssets,
+        refresh:     time.Duration(cfg.RefreshMinutes) * time.Minute,
+    }
+    if u.notional == "" {
+        u.notional = "USDT"
+    }
+    if u.refresh <= 0 {
+        u.refresh = time.Hour
+    }
+
+    for _, quote := range cfg.QuoteAssets {
+        u.quotes = append(u.quotes, strings.ToUpper(quote))
+    }
+    if len(u.quotes) == 0 {
+        u.quotes = []string{u.notional}
+    }
+    // Longest first so FDUSD is not taken for a USD pair
+    sort.Slice(u.quotes, func(i, j int) bool { return len(u.quotes[i]) > len(u.quotes[j]) })
+
+    for _, pattern := range cfg.ExcludePatterns {
+        re, err := regexp.Compile(pattern)
+        if err != nil {
+            return nil, fmt.Errorf("universe exclude pattern %q: %v", pattern, err)
+        }
+        u.excludes = append(u.excludes, re)
+    }
+
+    if cfg.ExcludeStablecoins {
+        stablecoins := cfg.Stablecoins
+        if len(stablecoins) == 0 {
+            stablecoins = DefaultStablecoins
+        }
+        u.stablecoins = upperSet(stablecoins)
+    }
+    return u, nil
+}
+
+func upperSet(values []string) map[string]bool {
+    set := make(map[string]bool, len(values))
+    for _, v := range values {
+        set[strings.ToUpper(v)] = true
+    }
+    return set
+}
+
+// refreshSymbols reloads exchangeInfo when it is older than the refresh
+// interval, or when forced at most once a minute. On failure the previous
+// symbols (if any) stay in use.
+func (u *Universe) refreshSymbols(force bool) {
+    age := time.Since(u.refreshed)
+    if u.symbols != nil && age < u.refresh && (!force || age < time.Minute) {
+        return
+    }
+
+    infos, err := u.client.GetExchangeInfo()
+    if err != nil {
+        log.Printf("⚠️  Universe: failed to get exchangeInfo: %v", err)
+        u.refreshed = time.Now().Add(-u.refresh + time.Minute) // Retry in a minute
+        return
+    }
+
+    u.symbols = make(map[string]types.SymbolInfo, len(infos))
+    u.bases = make(map[string]bool, len(infos))
+    for _, info := range infos {
+        u.symbols[info.Symbol] = info
+        u.bases[info.BaseAsset] = true
+    }
+    u.refreshed = time.Now()
+    log.Printf("🌐 Universe: loaded %d symbols from exchangeInfo", len(infos))
+}
+
+// Refresh reloads exchangeInfo now, e.g. when a ticker shows up for a symbol
+// it does not know yet. Reloads are limited to one a minute.
+func (u *Universe) Refresh() {
+    u.refreshSymbols(true)
+}
+
+// TradingSymbols returns the symbols exchangeInfo lists as TRADING, or nil
+// before exchangeInfo has been loaded
+func (u *Universe) TradingSymbols() map[string]bool {
+    if u.symbols == nil {
+        return nil
+    }
+    trading := map[string]bool{}
+    for symbol, info := range u.symbols {
+        if info.Status == "TRADING" {
+            trading[symbol] = true
+        }
+    }
+    return trading
+}
+
+// symbolInfo returns the exchangeInfo entry of a symbol. Without exchangeInfo
+// the pair is split on the configured quote assets and assumed tradable.
+func (u *Universe) symbolInfo(symbol string) (types.SymbolInfo, bool) {
+    if u.symbols != nil {
+        info, ok := u.symbols[symbol]
+        return info, ok
+    }
+    for _, quote := range u.quotes {
+        if len(symbol) > len(quote) && strings.HasSuffix(symbol, quote) {
+            return types.SymbolInfo{
+                Symbol:     symbol,
+                Status:     "TRADING",
+                BaseAsset:  strings.TrimSuffix(symbol, quote),
+                QuoteAsset: quote,
+            }, true
+        }
+    }
+    return types.SymbolInfo{}, false
+}
+
+// IsLeveragedToken reports whether a pair trades a leveraged token: it has
+// the LEVERAGED permission, or its base asset is a listed asset plus UP/DOWN/BULL/BEAR
+func IsLeveragedToken(info types.SymbolInfo, listedBases map[string]bool) bool {
+    for _, p := range info.Permissions {
+        if p == "LEVERAGED" {
+            return true
+        }
+    }
+    for _, suffix := range leveragedSuffixes {
+        underlying := strings.TrimSuffix(info.BaseAsset, suffix)
+        if underlying != info.BaseAsset && len(underlying) >= 2 && listedBases[underlying] {
+            return true
+        }
+    }
+    return false
+}
+
+// Exclusion returns why a pair is outside the universe, or "" if it is in
+func (u *Universe) Exclusion(info types.SymbolInfo) string {
+    inList := func(list map[string]bool) bool {
+        return list[info.Symbol] || list[info.BaseAsset]
+    }
+
+    switch {
+    case info.Status != "TRADING":
+        return "not trading"
+    case !u.isQuote(info.QuoteAsset):
+        return "quote asset"
+    case inList(u.blacklist):
+        return "blacklisted"
+    case len(u.whitelist) > 0:
+        // An explicit whitelist overrides the class exclusions
+        if !inList(u.whitelist) {
+            return "not whitelisted"
+        }
+        return ""
+    case u.stablecoins[info.BaseAsset]:
+        return "stablecoin"
+    case u.leveraged && IsLeveragedToken(info, u.bases):
+        return "leveraged token"
+    }
+
+    for _, re := range u.excludes {
+        if re.MatchString(info.Symbol) {
+            return "excluded by " + re.String()
+        }
+    }
+    return ""
+}
+
+func (u *Universe) isQuote(asset string) bool {
+    for _, quote := range u.quotes {
+        if quote == asset {
+            return true
+        }
+    }
+    return false
+}
+
+// notionalRates returns the value of each quote asset in the notional asset,
+// from the <quote><notional> ticker or the inverse <notional><quote> ticker
+func (u *Universe) notionalRates(tickers []types.Ticker) map[string]float64 {
+    prices := make(map[string]float64, len(tickers))
+    for _, t := range tickers {
+        prices[t.Symbol] = t.LastPrice
+    }
+
+    rates := map[string]float64{}
+    for _, quote := range u.quotes {
+        switch {
+        case quote == u.notional:
+            rates[quote] = 1
+        case prices[quote+u.notional] > 0:
+            rates[quote] = prices[quote+u.notional]
+        case prices[u.notional+quote] > 0:
+            rates[quote] = 1 / prices[u.notional+quote]
+        default:
+            log.Printf("⚠️  Universe: no %s%s or %s%s ticker - skipping %s pairs",
+                quote, u.notional, u.notional, quote, quote)
+        }
+    }
+    return rates
+}
+
+// Filter returns the tickers inside the universe with their base/quote assets
+// and notional volume filled in
+func (u *Universe) Filter(tickers []types.Ticker) []types.Ticker {
+    u.refreshSymbols(false)
+    rates := u.notionalRates(tickers)
+    u.rates = rates
+
+    if u.symbols == nil {
+        // No exchangeInfo yet - leveraged tokens are recognized from the tickers
+        u.bases = map[string]bool{}
+        for _, t := range tickers {
+            if info, ok := u.symbolInfo(t.Symbol); ok {
+                u.bases[info.BaseAsset] = true
+            }
+        }
+    }
+
+    selected := make([]types.Ticker, 0, len(tickers))
+    excluded := map[string]int{}
+    for _, t := range tickers {
+        t, reason := u.include(t, rates)
+        switch reason {
+        case "":
+            selected = append(selected, t)
+        case "unknown", "quote asset", "no notional rate":
+        default:
+            excluded[reason]++
+        }
+    }
+
+    if u.dedupeBases {
+        best := map[string]int{}
+        deduped := selected[:0]
+        for _, t := range selected {
+            if i, seen := best[t.BaseAsset]; seen {
+                if t.NotionalVolume > deduped[i].NotionalVolume {
+                    deduped[i] = t
+                }
+                excluded["duplicate base asset"]++
+                continue
+            }
+            best[t.BaseAsset] = len(deduped)
+            deduped = append(deduped, t)
+        }
+        selected = deduped
+    }
+
+    if len(excluded) > 0 {
+        reasons := make([]string, 0, len(excluded))
+        for reason, count := range excluded {
+            reasons = append(reasons, fmt.Sprintf("%s: %d", reason, count))
+        }
+        sort.Strings(reasons)
+        log.Printf("🌐 Universe: %d pairs in %s (excluded %s)",
+            len(selected), strings.Join(u.quotes, "/"), strings.Join(reasons, ", "))
+    }
+
+    return selected
+}
+
+// MarketPairs returns the tickers quoted in quote that count towards market
+// breadth: trading pairs other than stablecoins and leveraged tokens. The
+// universe's own lists don't apply, a whitelist would narrow the market to it.
+func (u *Universe) MarketPairs(tickers []types.Ticker, quote string) []types.Ticker {
+    u.refreshSymbols(false)
+
+    stablecoins := upperSet(DefaultStablecoins)
+    for coin := range u.stablecoins {
+        stablecoins[coin] = true
+    }
+    bases := u.bases
+    if u.symbols == nil {
+        bases = map[string]bool{}
+        for _, t := range tickers {
+            bases[strings.TrimSuffix(t.Symbol, quote)] = true
+        }
+    }
+
+    pairs := make([]types.Ticker, 0, len(tickers))
+    for _, t := range tickers {
+        info, ok := u.symbols[t.Symbol]
+        if u.symbols == nil {
+            info = types.SymbolInfo{Symbol: t.Symbol, Status: "TRADING",
+                BaseAsset: strings.TrimSuffix(t.Symbol, quote), QuoteAsset: quote}
+            ok = len(t.Symbol) > len(quote) && strings.HasSuffix(t.Symbol, quote)
+        }
+        if !ok || info.QuoteAsset != quote || info.Status != "TRADING" {
+            continue
+        }
+        if stablecoins[info.BaseAsset] || IsLeveragedToken(info, bases) {
+            continue
+        }
+        pairs = append(pairs, t)
+    }
+    return pairs
+}
+
+// Include checks a single ticker against the universe, using the notional
+// rates of the last Filter call. Returns the annotated ticker, or false.
+func (u *Universe) Include(t types.Ticker) (types.Ticker, bool) {
+    t, reason := u.include(t, u.rates)
+    return t, reason == ""
+}
+
+// include annotates a ticker with its assets and notional volume, or returns
+// why it is outside the universe
+func (u *Universe) include(t types.Ticker, rates map[string]float64) (types.Ticker, string) {
+    info, ok := u.symbolInfo(t.Symbol)
+    if !ok {
+        return t, "unknown"
+    }
+    if reason := u.Exclusion(info); reason != "" {
+        return t, reason
+    }
+    rate, ok := rates[info.QuoteAsset]
+    if !ok {
+        return t, "no notional rate"
+    }
+
+    t.BaseAsset = info.BaseAsset
+    t.QuoteAsset = info.QuoteAsset
+    t.NotionalRate = rate
+    t.NotionalVolume = t.QuoteVolume * rate
+    return t, ""
+}
+
+// NotionalVolume returns the ticker's 24h volume in the notional asset,
+// falling back to the quote volume for tickers that skipped the universe
+func NotionalVolume(t types.Ticker) float64 {
+    if t.NotionalRate > 0 {
+        return t.NotionalVolume
+    }
+    return t.QuoteVolume
+}
@@ -0,0 +1,290 @@
+// File: internal/strategy/windows.go
+// ============================================
+package strategy
+
+import (
+    "binance-trading-bot/internal/binance"
+    "binance-trading-bot/pkg/types"
+    "fmt"
+    "log"
+    "sort"
+    "strconv"
+    "strings"
+    "time"
+)
+
+// WindowStats is a coin's price change and volume over one rolling window
+type WindowStats struct {
+    Change      float64 // Price change (%)
+    QuoteVolume float64 // Quote volume traded in the window
+}
+
+type hotWindow struct {
+    name     string // As configured, e.g. "1h" - used in variable names
+    duration time.Duration
+    bars     int // Klines of the chosen interval in the window (klines source)
+}
+
+// hotIntervals are the kline intervals the klines source can measure with
+var hotIntervals = []struct {
+    name     string
+    duration time.Duration
+}{
+    {"1m", time.Minute}, {"3m", 3 * time.Minute}, {"5m", 5 * time.Minute},
+    {"15m", 15 * time.Minute}, {"30m", 30 * time.Minute}, {"1h", time.Hour},
+    {"2h", 2 * time.Hour}, {"4h", 4 * time.Hour},
+}
+
+// HotFilter selects hot coins with an expression over rolling-window changes
+// and volumes, e.g. change_1h >= 3 && volume_ratio_1h >= 2. Window stats come
+// from Binance's rolling-window ticker or are computed locally from klines.
+type HotFilter struct {
+    client     *binance.Client
+    windows    []hotWindow
+    source     string
+    filter     *Expr
+    maxSymbols int
+    interval   string // Kline interval for the local source
+    bars       int    // Klines fetched for the local source
+}
+
+// ParseWindow parses a window such as "15m", "4h" or "1d"
+func ParseWindow(window string) (time.Duration, error) {
+    if len(window) < 2 {
+        return 0, fmt.Errorf("invalid window %q", window)
+    }
+    n, err := strconv.Atoi(window[:len(window)-1])
+    if err != nil || n <= 0 {
+        return 0, fmt.Errorf("invalid window %q", window)
+    }
+    switch window[len(window)-1] {
+    case 'm':
+        return time.Duration(n) * time.Minute, nil
+    case 'h':
+        return time.Duration(n) * time.Hour, nil
+    case 'd':
+        return time.Duration(n) * 24 * time.Hour, nil
+    }
+    return 0, fmt.Errorf("invalid window %q (use m, h or d)", window)
+}
+
+// validTickerWindow reports whether the rolling-window ticker accepts a window
+func validTickerWindow(window string) bool {
+    n, _ := strconv.Atoi(window[:len(window)-1])
+    switch window[len(window)-1] {
+    case 'm':
+        return n <= 59
+    case 'h':
+        return n <= 23
+    case 'd':
+        return n <= 7
+    }
+    return false
+}
+
+// hotFilterVars lists the variables available to the hot-coin filter
+func hotFilterVars(windows []hotWindow) map[string]bool {
+    vars := map[string]bool{
+        "price": true, "price_change": true, "quote_volume": true, "volume": true,
+    }
+    for _, w := range windows {
+        vars["change_"+w.name] = true
+        vars["quote_volume_"+w.name] = true
+        vars["volume_ratio_"+w.name] = true
+    }
+    return vars
+}
+
+// NewHotFilter compiles the hot-coin filter from config. Returns nil when no
+// filter is configured and the 24h change filter applies.
+func NewHotFilter(config *types.Config, client *binance.Client) (*HotFilter, error) {
+    cfg := config.Strategy.HotWindows
+    if cfg.Filter == "" {
+        return nil, nil
+    }
+
+    f := &HotFilter{
+        client:     client,
+        source:     cfg.Source,
+        maxSymbols: cfg.MaxSymbols,
+    }
+    if f.source == "" {
+        f.source = "ticker"
+    }
+    if f.source != "ticker" && f.source != "klines" {
+        return nil, fmt.Errorf("hot window source %q (expected ticker or klines)", f.source)
+    }
+    if f.maxSymbols <= 0 {
+        f.maxSymbols = 50
+    }
+
+    names := cfg.Windows
+    if len(names) == 0 {
+        names = []string{"1h", "4h"}
+    }
+    longest := time.Duration(0)
+    for _, name := range names {
+        duration, err := ParseWindow(name)
+        if err != nil {
+            return nil, err
+        }
+        if f.source == "ticker" && !validTickerWindow(name) {
+            return nil, fmt.Errorf("window %q is not supported by the rolling-window ticker (1m-59m, 1h-23h, 1d-7d)", name)
+        }
+        f.windows = append(f.windows, hotWindow{name: name, duration: duration})
+        if duration > longest {
+            longest = duration
+        }
+    }
+
+    // Finest kline interval that divides every window and covers the longest
+    // in one request, so no window is rounded to a different length
+    for _, interval := range hotIntervals {
+        if bars := int(longest / interval.duration); bars < 1000 && dividesAll(f.windows, interval.duration) {
+            f.interval, f.bars = interval.name, bars+1
+            for i := range f.windows {
+                f.windows[i].bars = int(f.windows[i].duration / interval.duration)
+            }
+            break
+        }
+    }
+    if f.source == "klines" && f.interval == "" {
+        return nil, fmt.Errorf("no kline interval measures windows %s exactly within 1000 bars (use closer windows or the ticker source)",
+            strings.Join(names, ", "))
+    }
+
+    filter, err := CompileExpr(cfg.Filter, hotFilterVars(f.windows))
+    if err != nil {
+        return nil, fmt.Errorf("hot filter: %v", err)
+    }
+    if len(filter.Timeframes()) > 0 {
+        return nil, fmt.Errorf("hot filter cannot use kline functions")
+    }
+    f.filter = filter
+
+    return f, nil
+}
+
+func dividesAll(windows []hotWindow, interval time.Duration) bool {
+    for _, w := range windows {
+        if w.duration%interval != 0 {
+            return false
+        }
+    }
+    return true
+}
+
+// WindowStatsFromKlines computes the change and quote volume of the last
+// bars klines. False when there is not enough history.
+func WindowStatsFromKlines(klines []types.Kline, bars int) (WindowStats, bool) {
+    change, ok := windowChange(klines, bars)
+    if !ok {
+        return WindowStats{}, false
+    }
+    stats := WindowStats{Change: change}
+    for _, k := range klines[len(klines)-bars:] {
+        stats.QuoteVolume += k.QuoteVolume
+    }
+    return stats, true
+}
+
+// stats returns the window stats of each symbol, keyed by symbol and window name
+func (f *HotFilter) stats(coins []types.Ticker) map[string]map[string]WindowStats {
+    stats := make(map[string]map[string]WindowStats, len(coins))
+    for _, coin := range coins {
+        stats[coin.Symbol] = map[string]WindowStats{}
+    }
+
+    if f.source == "ticker" {
+        symbols := make([]string, len(coins))
+        for i, coin := range coins {
+            symbols[i] = coin.Symbol
+        }
+        for _, w := range f.windows {
+            tickers, err := f.client.GetRollingTickers(symbols, w.name)
+            if err != nil {
+                log.Printf("⚠️  Hot filter: failed to get %s rolling tickers: %v", w.name, err)
+                continue
+            }
+            for _, t := range tickers {
+                if _, ok := stats[t.Symbol]; ok {
+                    stats[t.Symbol][w.name] = WindowStats{Change: t.PriceChangePercent, QuoteVolume: t.QuoteVolume}
+                }
+            }
+        }
+        return stats
+    }
+
+    for _, coin := range coins {
+        klines, err := f.client.GetKlines(coin.Symbol, f.interval, f.bars)
+        if err != nil {
+            log.Printf("⚠️  Hot filter: failed to get %s %s klines: %v", coin.Symbol, f.interval, err)
+            continue
+        }
+        for _, w := range f.windows {
+            if ws, ok := WindowStatsFromKlines(klines, w.bars); ok {
+                stats[coin.Symbol][w.name] = ws
+            }
+        }
+    }
+    return stats
+}
+
+// vars builds the filter variables of a coin. Volume ratios compare the
+// window's hourly volume rate with the 24h hourly average.
+func (f *HotFilter) vars(coin types.Ticker, windows map[string]WindowStats) map[string]float64 {
+    rate := coin.NotionalRate
+    if rate <= 0 {
+        rate = 1
+    }
+    vars := map[string]float64{
+        "price":        coin.LastPrice,
+        "price_change": coin.PriceChangePercent,
+        "quote_volume": NotionalVolume(coin),
+        "volume":       coin.Volume,
+    }
+    hourlyAverage := coin.QuoteVolume / 24
+    for _, w := range f.windows {
+        ws, ok := windows[w.name]
+        if !ok {
+            continue // Missing variables fail the filter
+        }
+        vars["change_"+w.name] = ws.Change
+        vars["quote_volume_"+w.name] = ws.QuoteVolume * rate
+        if hourlyAverage > 0 {
+            vars["volume_ratio_"+w.name] = ws.QuoteVolume / w.duration.Hours() / hourlyAverage
+        }
+    }
+    return vars
+}
+
+// Filter measures the most liquid coins over the rolling windows and returns
+// those that pass the filter expression
+func (f *HotFilter) Filter(coins []types.Ticker) []types.Ticker {
+    sort.SliceStable(coins, func(i, j int) bool { return NotionalVolume(coins[i]) > NotionalVolume(coins[j]) })
+    if len(coins) > f.maxSymbols {
+        coins = coins[:f.maxSymbols]
+    }
+
+    stats := f.stats(coins)
+    passed := []types.Ticker{}
+    for _, coin := range coins {
+        env := &rankEnv{vars: f.vars(coin, stats[coin.Symbol])}
+        ok, err := f.filter.EvalBool(env)
+        if err != nil || !ok {
+            continue
+        }
+        passed = append(passed, coin)
+
+        parts := ""
+        for _, w := range f.windows {
+            if ws, ok := stats[coin.Symbol][w.name]; ok {
+                parts += fmt.Sprintf(" | %s %+.2f%% (%.1fx vol)", w.name, ws.Change, env.vars["volume_ratio_"+w.name])
+            }
+        }
+        log.Printf("   ⏱️  %s passed the hot filter%s", coin.Symbol, parts)
+    }
+
+    log.Printf("⏱️  Hot filter: %d of %d coins passed (%s source)", len(passed), len(coins), f.source)
+    return passed
+}
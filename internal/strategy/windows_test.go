@@ -0,0 +1,58 @@
+// File: internal/strategy/windows_test.go
+// ============================================
+package strategy
+
+import (
+    "testing"
+
+    "binance-trading-bot/pkg/types"
+)
+
+func TestHotFilterKlineInterval(t *testing.T) {
+    tests := []struct {
+        name         string
+        windows      []string
+        wantInterval string
+        wantBars     map[string]int
+        wantErr      bool
+    }{
+        {name: "default windows", windows: []string{"1h", "4h"}, wantInterval: "1m",
+            wantBars: map[string]int{"1h": 60, "4h": 240}},
+        {name: "odd minutes stay exact", windows: []string{"7m", "1h"}, wantInterval: "1m",
+            wantBars: map[string]int{"7m": 7, "1h": 60}},
+        {name: "coarser interval that divides both", windows: []string{"15m", "1d"}, wantInterval: "3m",
+            wantBars: map[string]int{"15m": 5, "1d": 480}},
+        {name: "week on 15m bars", windows: []string{"1h", "7d"}, wantInterval: "15m",
+            wantBars: map[string]int{"1h": 4, "7d": 672}},
+        {name: "minute window with a day", windows: []string{"1m", "1d"}, wantErr: true},
+        {name: "odd minutes with a day", windows: []string{"7m", "1d"}, wantErr: true},
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            config := &types.Config{}
+            config.Strategy.HotWindows.Windows = tt.windows
+            config.Strategy.HotWindows.Source = "klines"
+            config.Strategy.HotWindows.Filter = "change_" + tt.windows[0] + " > 1"
+
+            f, err := NewHotFilter(config, nil)
+            if tt.wantErr {
+                if err == nil {
+                    t.Fatalf("expected an error, got interval %s", f.interval)
+                }
+                return
+            }
+            if err != nil {
+                t.Fatalf("NewHotFilter: %v", err)
+            }
+            if f.interval != tt.wantInterval {
+                t.Errorf("interval = %s, want %s", f.interval, tt.wantInterval)
+            }
+            for _, w := range f.windows {
+                if w.bars != tt.wantBars[w.name] {
+                    t.Errorf("%s bars = %d, want %d", w.name, w.bars, tt.wantBars[w.name])
+                }
+            }
+        })
+    }
+}
@@ -5,7 +5,9 @@ package telegram
 import (
     "binance-trading-bot/pkg/types" 
     "fmt"
+    "html"
     "io"
+    "math"
     "net/http"
     "net/url"
     "time"
@@ -41,7 +43,11 @@ func (n *Notifier) NotifyTradeAlert(signal types.Signal, stopLoss, takeProfit, q
     
     msg += "<b>📋 TRADE SETUP:</b>\n"
     msg += fmt.Sprintf("💰 Entry: <code>$%.4f</code>\n", signal.Price)
-    msg += fmt.Sprintf("📦 Quantity: <code>%.4f</code> (~$%.2f)\n", quantity, quantity*signal.Price)
+    notionalRate := signal.NotionalRate
+    if notionalRate <= 0 {
+        notionalRate = 1
+    }
+    msg += fmt.Sprintf("📦 Quantity: <code>%.4f</code> (~$%.2f)\n", quantity, quantity*signal.Price*notionalRate)
     msg += fmt.Sprintf("🛑 Stop Loss: <code>$%.4f</code> (-%.1f%%)\n", 
         stopLoss, ((signal.Price-stopLoss)/signal.Price)*100)
     msg += fmt.Sprintf("🎯 Take Profit: <code>$%.4f</code> (+%.1f%%)\n\n", 
@@ -51,11 +57,28 @@ func (n *Notifier) NotifyTradeAlert(signal types.Signal, stopLoss, takeProfit, q
     riskReward := ((takeProfit - signal.Price) / (signal.Price - stopLoss))
     msg += fmt.Sprintf("⚖️ Risk/Reward: <b>1:%.2f</b>\n\n", riskReward)
     
+    if a := signal.Anomaly; a != nil && len(a.Flags) > 0 {
+        if a.Flagged {
+            msg += "🚩 <b>ANOMALY RISK:</b>\n"
+        } else {
+            msg += "⚠️ <b>Anomaly warnings:</b>\n"
+        }
+        for _, flag := range a.Flags {
+            msg += fmt.Sprintf("• %s\n", html.EscapeString(flag))
+        }
+        msg += fmt.Sprintf("<code>Spread %.2f%% | Top trades %.0f%% | Candle %.1f ATR</code>\n\n",
+            a.SpreadPercent, a.TopTradeShare*100, a.CandleATR)
+    }
+    
     msg += "<b>💡 ANALYSIS:</b>\n"
-    // Split reason into lines and format nicely
-    reasonLines := strings.Split(signal.Reason, "\n")
-    for _, line := range reasonLines {
-        msg += fmt.Sprintf("<code>%s</code>\n", strings.TrimSpace(line))
+    if signal.Explanation != nil {
+        msg += formatExplanation(signal.Explanation)
+    } else {
+        // Split reason into lines and format nicely
+        reasonLines := strings.Split(signal.Reason, "\n")
+        for _, line := range reasonLines {
+            msg += fmt.Sprintf("<code>%s</code>\n", html.EscapeString(strings.TrimSpace(line)))
+        }
     }
     
     msg += "\n" + strings.Repeat("━", 30) + "\n"
@@ -65,6 +88,55 @@ func (n *Notifier) NotifyTradeAlert(signal types.Signal, stopLoss, takeProfit, q
     n.sendMessage(msg)
 }
 
+// formatExplanation renders the structured signal breakdown as Telegram HTML
+func formatExplanation(e *types.SignalExplanation) string {
+    msg := fmt.Sprintf("⚙️ Score <b>%.0f/%.0f</b> | Strength <b>%.0f%%</b> vs threshold %.0f%% | %s (%.0f%%)\n",
+        e.Score, e.MaxScore, e.Strength*100, e.Threshold*100, e.Regime, e.RegimeConfidence*100)
+    if e.ModelProbability != nil {
+        // The strength above is the model blend, not the rule score
+        ruleStrength := 0.0
+        if e.MaxScore > 0 {
+            ruleStrength = math.Max(e.Score/e.MaxScore*100, 0)
+        }
+        msg += fmt.Sprintf("🤖 Model probability <b>%.0f%%</b> | Rules %.0f%%\n", *e.ModelProbability*100, ruleStrength)
+    }
+    
+    passed, missed := []string{}, []string{}
+    for _, c := range e.Criteria {
+        detail := html.EscapeString(c.Detail)
+        switch {
+        case c.Type == "scored" && c.Passed && c.Points != 0:
+            passed = append(passed, fmt.Sprintf("✅ %s <i>(%+.0f)</i>", detail, c.Points))
+        case c.Type == "scored" && !c.Passed && c.Weight > 0:
+            missed = append(missed, fmt.Sprintf("▫️ %s <i>(0/%.0f)</i>", detail, c.Weight))
+        case c.Type == "required" && c.Passed:
+            passed = append(passed, fmt.Sprintf("🔒 %s", detail))
+        }
+    }
+    if len(passed) > 0 {
+        msg += strings.Join(passed, "\n") + "\n"
+    }
+    if len(missed) > 0 {
+        msg += strings.Join(missed, "\n") + "\n"
+    }
+    
+    if len(e.Timeframes) > 0 {
+        msg += "\n<b>⏱ TIMEFRAMES:</b>\n"
+        for _, tf := range e.Timeframes {
+            msg += fmt.Sprintf("<code>%-4s %-8s %3.0f%% RSI %4.1f</code>\n", tf.Timeframe, tf.Trend, tf.Strength*100, tf.RSI)
+        }
+    }
+    
+    if len(e.Patterns) > 0 {
+        msg += fmt.Sprintf("🕯 %s\n", html.EscapeString(strings.Join(e.Patterns, ", ")))
+    }
+    for _, note := range e.Notes {
+        msg += fmt.Sprintf("ℹ️ %s\n", html.EscapeString(note))
+    }
+    
+    return msg
+}
+
 func (n *Notifier) sendMessage(message string) error {
     if !n.enabled {
         log.Println("⚠️ Telegram notifications disabled in config")
@@ -123,6 +195,17 @@ func (n *Notifier) NotifyHotCoins(coins []string) {
     n.sendMessage(msg)
 }
 
+// NotifyNewListing announces a symbol that just started trading
+func (n *Notifier) NotifyNewListing(symbol, quoteAsset string, price, quoteVolume float64, source string) {
+    msg := "🆕 <b>NEW LISTING</b>\n\n"
+    msg += fmt.Sprintf("Symbol: <b>%s</b>\n", symbol)
+    msg += fmt.Sprintf("Price: <code>%.8g %s</code>\n", price, quoteAsset)
+    msg += fmt.Sprintf("Volume so far: %.0f %s\n", quoteVolume, quoteAsset)
+    msg += fmt.Sprintf("Detected via: %s\n", source)
+    msg += "\n⏳ Watching with the new-listing analysis..."
+    n.sendMessage(msg)
+}
+
 func (n *Notifier) NotifyPositionOpened(symbol string, price, stopLoss, takeProfit float64, reason string) {
     msg := fmt.Sprintf("📈 <b>POSITION OPENED</b>\n\n")
     msg += fmt.Sprintf("Symbol: <b>%s</b>\n", symbol)
@@ -139,10 +222,12 @@ func (n *Notifier) NotifyPositionClosed(symbol string, pnl, pnlPercent float64,
         emoji = "❌"
     }
     
-    msg := fmt.Sprintf("%s <b>POSITION CLOSED</b>\n\n", emoji)
+    msg := fmt.Sprintf("%s <b>EXIT ALERT</b>\n\n", emoji)
     msg += fmt.Sprintf("Symbol: <b>%s</b>\n", symbol)
     msg += fmt.Sprintf("PnL: <b>%.2f USDT (%.2f%%)</b>\n", pnl, pnlPercent)
     msg += fmt.Sprintf("\n💡 Reason: %s", reason)
+    msg += "\n\n⚠️ <b>MANUAL EXECUTION REQUIRED</b>\n"
+    msg += "Close the trade on Binance if you took it"
     n.sendMessage(msg)
 }
 
@@ -168,6 +253,24 @@ func (n *Notifier) NotifyDailyReport(positions int, dailyPnL float64, openPnL fl
     n.sendMessage(msg)
 }
 
+// NotifyDrawdown reports equity crossing a drawdown limit. flatten is true
+// for the hard limit at which every tracked setup is exited.
+func (n *Notifier) NotifyDrawdown(equity, peak, drawdownPercent, limitPercent float64, asset string, flatten bool) {
+    msg := "🛑 <b>MAX DRAWDOWN REACHED</b>\n\n"
+    if flatten {
+        msg = "🚨 <b>DRAWDOWN HARD LIMIT - FLATTENING</b>\n\n"
+    }
+    msg += fmt.Sprintf("Equity: <b>%.2f %s</b>\n", equity, asset)
+    msg += fmt.Sprintf("Peak: %.2f %s\n", peak, asset)
+    msg += fmt.Sprintf("Drawdown: <b>%.2f%%</b> (limit %.2f%%)\n", drawdownPercent, limitPercent)
+    if flatten {
+        msg += "\n⚠️ Exit every open trade - exit alerts follow for the tracked setups"
+    } else {
+        msg += "\n⏸️ New entries blocked until equity recovers"
+    }
+    n.sendMessage(msg)
+}
+
 func (n *Notifier) NotifyError(errorMsg string) {
     msg := fmt.Sprintf("⚠️ <b>Error Alert</b>\n\n%s", errorMsg)
     n.sendMessage(msg)
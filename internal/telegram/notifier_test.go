@@ -0,0 +1,49 @@
+// File: internal/telegram/notifier_test.go
+// ============================================
+package telegram
+
+import (
+    "strings"
+    "testing"
+
+    "binance-trading-bot/pkg/types"
+)
+
+func TestFormatExplanationHeader(t *testing.T) {
+    probability := 0.82
+    tests := []struct {
+        name        string
+        explanation types.SignalExplanation
+        want        []string
+        absent      []string
+    }{
+        {
+            name:        "rules only",
+            explanation: types.SignalExplanation{Score: 60, MaxScore: 100, Strength: 0.6, Threshold: 0.55, Regime: "TRENDING"},
+            want:        []string{"Score <b>60/100</b>", "Strength <b>60%</b> vs threshold 55%"},
+            absent:      []string{"Model probability"},
+        },
+        {
+            name: "model blend",
+            explanation: types.SignalExplanation{Score: 50, MaxScore: 100, Strength: 0.71, Threshold: 0.65,
+                Regime: "TRENDING", ModelProbability: &probability},
+            want: []string{"Strength <b>71%</b> vs threshold 65%", "Model probability <b>82%</b> | Rules 50%"},
+        },
+    }
+
+    for _, tt := range tests {
+        t.Run(tt.name, func(t *testing.T) {
+            msg := formatExplanation(&tt.explanation)
+            for _, want := range tt.want {
+                if !strings.Contains(msg, want) {
+                    t.Errorf("missing %q in:\n%s", want, msg)
+                }
+            }
+            for _, absent := range tt.absent {
+                if strings.Contains(msg, absent) {
+                    t.Errorf("unexpected %q in:\n%s", absent, msg)
+                }
+            }
+        })
+    }
+}
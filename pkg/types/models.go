@@ -10,6 +10,9 @@ type Config struct {
         APIKey    string `yaml:"api_key"`
         SecretKey string `yaml:"secret_key"`
         Testnet   bool   `yaml:"testnet"`
+        
+        RequestWeightPerMinute int `yaml:"request_weight_per_minute"` // Rate limiter budget
+        KlineCacheTTLSeconds   int `yaml:"kline_cache_ttl_seconds"`   // Reuse fetched klines this long
     } `yaml:"binance"`
     
     Telegram struct {
@@ -29,28 +32,304 @@ type Config struct {
         MinPriceChange        float64 `yaml:"min_price_change_percent"`
         UseMultiTimeframe     bool    `yaml:"use_multi_timeframe"`
         MinSignalStrength     float64 `yaml:"min_signal_strength"`
+        AnalysisWorkers       int     `yaml:"analysis_workers"` // Hot coins analyzed in parallel
         RequireVolumeSpike    bool    `yaml:"require_volume_spike"`
         VolumeSpikeMultiplier float64 `yaml:"volume_spike_multiplier"`
         MaxRSIEntry           float64 `yaml:"max_rsi_entry"`
         MinRSIEntry           float64 `yaml:"min_rsi_entry"`
         RequireEMACrossover   bool    `yaml:"require_ema_crossover"`
         RequireMACDPositive   bool    `yaml:"require_macd_positive"`
+        
+        // Debounce: BUY signals must repeat before they fire
+        Confirmation struct {
+            Enabled            bool    `yaml:"enabled"`
+            MinEvaluations     int     `yaml:"min_evaluations"`      // Consecutive BUY evaluations that confirm
+            MinDurationSeconds int     `yaml:"min_duration_seconds"` // Or time held above threshold that confirms
+            MaxRunPercent      float64 `yaml:"max_run_percent"`      // Invalidate when price runs further past the first trigger
+        } `yaml:"confirmation"`
+        
+        // Picking the best BUY signals of a cycle
+        SignalRanking struct {
+            MaxAlertsPerCycle int     `yaml:"max_alerts_per_cycle"` // Also capped by the free position slots
+            StrengthWeight    float64 `yaml:"strength_weight"`
+            RiskRewardWeight  float64 `yaml:"risk_reward_weight"`
+            LiquidityWeight   float64 `yaml:"liquidity_weight"`
+            TargetRiskReward  float64 `yaml:"target_risk_reward"` // Risk/reward that scores full marks
+        } `yaml:"signal_ranking"`
+        
+        // Rule engine (falls back to the legacy knobs above when empty)
+        EntryRules       []RuleConfig       `yaml:"entry_rules"`
+        RegimeThresholds map[string]float64 `yaml:"regime_thresholds"`
+        
+        // Tradable pairs considered by FindHotCoins
+        Universe struct {
+            QuoteAssets        []string `yaml:"quote_assets"`        // e.g. [USDT, FDUSD, BTC]
+            NotionalAsset      string   `yaml:"notional_asset"`      // Volumes and sizes converted to this asset
+            Whitelist          []string `yaml:"whitelist"`           // Only these symbols or base assets when set
+            Blacklist          []string `yaml:"blacklist"`           // Symbols or base assets never considered
+            ExcludePatterns    []string `yaml:"exclude_patterns"`    // Regular expressions matched against the symbol
+            ExcludeStablecoins bool     `yaml:"exclude_stablecoins"` // Drop pairs whose base asset is a stablecoin
+            Stablecoins        []string `yaml:"stablecoins"`         // Overrides the built-in stablecoin list
+            ExcludeLeveraged   bool     `yaml:"exclude_leveraged"`   // Drop UP/DOWN/BULL/BEAR leveraged tokens
+            DedupeBaseAssets   bool     `yaml:"dedupe_base_assets"`  // Keep only the most liquid quote per base asset
+            RefreshMinutes     int      `yaml:"refresh_minutes"`     // exchangeInfo refresh interval
+        } `yaml:"universe"`
+        
+        // Newly listed symbols get their own analysis, GenerateSignal needs more history
+        NewListings struct {
+            Enabled            bool    `yaml:"enabled"`
+            TrackMinutes       int     `yaml:"track_minutes"`        // How long a listing uses the listing analysis
+            Timeframe          string  `yaml:"timeframe"`            // Kline interval for the listing analysis
+            MinBars            int     `yaml:"min_bars"`             // Bars traded before a listing can signal
+            MinQuoteVolume     float64 `yaml:"min_quote_volume"`     // 24h volume in the notional asset
+            MinChangePercent   float64 `yaml:"min_change_percent"`   // Above the first traded price
+            MaxPullbackPercent float64 `yaml:"max_pullback_percent"` // Below the high since listing
+            RequireAboveVWAP   bool    `yaml:"require_above_vwap"`   // Price above the VWAP since listing
+            MomentumBars       int     `yaml:"momentum_bars"`        // Close above the close this many bars ago
+            MinStrength        float64 `yaml:"min_strength"`         // Share of the listing score needed for BUY
+        } `yaml:"new_listings"`
+        
+        // Pump-and-dump / wash-trading checks from klines, aggTrades and depth
+        Anomaly struct {
+            Enabled              bool    `yaml:"enabled"`
+            Timeframe            string  `yaml:"timeframe"`               // Kline interval for the candle checks
+            LookbackBars         int     `yaml:"lookback_bars"`           // Recent bars checked for spikes and wicks
+            MaxCandleATR         float64 `yaml:"max_candle_atr"`          // Largest body in ATRs of the bars before the lookback
+            MaxUpperWickRatio    float64 `yaml:"max_upper_wick_ratio"`    // Upper wicks / bodies over the lookback
+            Trades               int     `yaml:"trades"`                  // aggTrades fetched
+            TopTrades            int     `yaml:"top_trades"`              // Largest trades measured for concentration
+            MaxTopTradeShare     float64 `yaml:"max_top_trade_share"`     // Volume share of the largest trades
+            MaxRepeatedSizeShare float64 `yaml:"max_repeated_size_share"` // Trades repeating another trade's size
+            MaxSpreadPercent     float64 `yaml:"max_spread_percent"`      // Best bid/ask spread
+            MinDepthNotional     float64 `yaml:"min_depth_notional"`      // Book depth within 2% of mid
+            MinFlags             int     `yaml:"min_flags"`               // Findings that raise the risk flag
+        } `yaml:"anomaly"`
+        
+        // Hot coin detection over rolling windows instead of only the 24h change
+        HotWindows struct {
+            Windows    []string `yaml:"windows"`     // e.g. ["1h", "4h"]
+            Source     string   `yaml:"source"`      // "ticker" (rolling-window API) or "klines"
+            Filter     string   `yaml:"filter"`      // Expression over the window variables, replaces min_price_change_percent
+            MaxSymbols int      `yaml:"max_symbols"` // Most liquid universe pairs measured per cycle
+        } `yaml:"hot_windows"`
+        
+        // Relative volume against time-of-day baselines
+        RVOL struct {
+            Enabled        bool    `yaml:"enabled"`
+            Days           int     `yaml:"days"`            // History behind the hourly baselines (max 41)
+            RefreshMinutes int     `yaml:"refresh_minutes"` // Baseline rebuild interval per symbol
+            MinRVOL        float64 `yaml:"min_rvol"`        // Hot coins need at least this RVOL, 0 = off
+        } `yaml:"rvol"`
+        
+        // Hot coin ranking (relative strength vs BTCUSDT)
+        Ranking struct {
+            Formula       string `yaml:"formula"`        // Expression, defaults to price_change * 2 + quote_volume / 1000000
+            Timeframe     string `yaml:"timeframe"`      // Kline interval for RS windows and beta
+            Windows       []int  `yaml:"windows"`        // RS lookbacks in bars (rs_<bars> variables)
+            BetaBars      int    `yaml:"beta_bars"`      // Bars used for the rolling beta
+            MaxCandidates int    `yaml:"max_candidates"` // Coins to fetch klines for
+            TopN          int    `yaml:"top_n"`          // Hot coins kept after ranking
+        } `yaml:"ranking"`
+        
+        // Market-wide filter from BTC/ETH trend and breadth
+        MarketFilter struct {
+            Enabled               bool    `yaml:"enabled"`
+            QuoteAsset            string  `yaml:"quote_asset"`              // Pairs used for breadth
+            TrendTimeframe        string  `yaml:"trend_timeframe"`          // BTC/ETH klines for DetectTrend
+            BTCBearishStrength    float64 `yaml:"btc_bearish_strength"`     // Risk-off when BTC is bearish at least this strong
+            MinAdvanceDecline     float64 `yaml:"min_advance_decline"`      // Risk-off below this advancers/decliners ratio
+            MinAboveVWAP          float64 `yaml:"min_above_vwap"`           // Risk-off below this share above 24h VWAP
+            VolumeLookbackMinutes int     `yaml:"volume_lookback_minutes"`  // Window for the aggregate volume change
+            VolumeSymbols         int     `yaml:"volume_symbols"`           // Most liquid pairs whose 1m klines make up the volume change
+            RiskOffThresholdBoost float64 `yaml:"risk_off_threshold_boost"` // Added to the entry threshold when risk-off
+        } `yaml:"market_filter"`
+        
+        // Strategy exits for open positions (on top of the risk manager stops)
+        ExitSignals struct {
+            Enabled        bool    `yaml:"enabled"`
+            MTFFlip        bool    `yaml:"mtf_flip"`         // Exit when the MTF score drops below mtf_exit_score
+            MTFExitScore   float64 `yaml:"mtf_exit_score"`
+            MACDCrossDown  bool    `yaml:"macd_cross_down"`  // Exit on a 5m MACD cross below signal
+            VWAPLoss       bool    `yaml:"vwap_loss"`        // Exit when the 5m close crosses below VWAP
+            MinHoldMinutes int     `yaml:"min_hold_minutes"` // Ignore strategy exits right after entry
+        } `yaml:"exit_signals"`
+        
+        // Scoring model trained on exported features
+        ML struct {
+            Enabled           bool    `yaml:"enabled"`
+            ModelPath         string  `yaml:"model_path"`          // JSON model file
+            Mode              string  `yaml:"mode"`                // "blend" or "replace"
+            BlendWeight       float64 `yaml:"blend_weight"`        // Model share of the strength in blend mode
+            FeatureExportPath string  `yaml:"feature_export_path"` // Append features of every evaluation (JSON lines)
+        } `yaml:"ml"`
+        
+        // Price/oscillator divergences on the multi-timeframe klines
+        Divergence struct {
+            Enabled            bool     `yaml:"enabled"`
+            Indicators         []string `yaml:"indicators"` // RSI, MACD, OBV
+            SwingBars          int      `yaml:"swing_bars"` // Bars on each side of a swing
+            MaxAgeBars         int      `yaml:"max_age_bars"`
+            IncludeUnconfirmed bool     `yaml:"include_unconfirmed"` // Treat the latest bar as a swing
+        } `yaml:"divergence"`
+        
+        // Custom conditions, e.g. rsi(14,"15m") < 35 && close > ema(200,"1h")
+        Expressions struct {
+            Entry []string `yaml:"entry"` // All must be true to enter
+            Exit  []string `yaml:"exit"`  // Any true closes an open position
+            Veto  []string `yaml:"veto"`  // Any true rejects the entry
+        } `yaml:"expressions"`
     } `yaml:"strategy"`
     
     Risk struct {
         MaxDailyLoss float64 `yaml:"max_daily_loss_usdt"`
         MaxDrawdown  float64 `yaml:"max_drawdown_percent"`
+        
+        // Exit alert for every tracked setup at this drawdown from peak equity (0 disables)
+        FlattenDrawdown float64 `yaml:"flatten_drawdown_percent"`
+        
+        // Peak equity survives restarts in this file; blocked entries reset the
+        // peak to current equity after DrawdownResetHours (0 = only on recovery)
+        EquityStatePath    string  `yaml:"equity_state_path"`
+        DrawdownResetHours float64 `yaml:"drawdown_reset_hours"`
+        
+        // Tracked alert setups survive restarts in this file (default: next to
+        // the trade history, when there is one)
+        SetupsPath string `yaml:"setups_path"`
+        
+        // How alerts are sized
+        PositionSizing struct {
+            Mode                 string  `yaml:"mode"`                    // "dynamic" (position_size_usdt scaled), "risk_percent" or "kelly"
+            RiskPercent          float64 `yaml:"risk_percent"`            // Equity lost if the stop is hit (risk_percent mode)
+            MinStopPercent       float64 `yaml:"min_stop_percent"`        // Size as if the stop were at least this far (and 1 ATR) away
+            MaxNotionalPerSymbol float64 `yaml:"max_notional_per_symbol"` // Cap on one position's value (0 = none)
+            
+            // Kelly mode: equity fraction from each strategy's trade history
+            Kelly struct {
+                Fraction    float64 `yaml:"fraction"`     // Share of full Kelly to use
+                MinFraction float64 `yaml:"min_fraction"` // Floor on the equity fraction (0 = skip without an edge)
+                MaxFraction float64 `yaml:"max_fraction"` // Cap on the equity fraction
+                MinTrades   int     `yaml:"min_trades"`   // Below this, fall back to dynamic sizing
+                MaxTrades   int     `yaml:"max_trades"`   // Most recent trades per strategy in the estimate
+                HistoryPath string  `yaml:"history_path"` // Persisted trade history (JSON lines)
+            } `yaml:"kelly"`
+        } `yaml:"position_sizing"`
+        
+        // Move stops/targets onto nearby support and resistance levels
+        SnapToLevels struct {
+            Enabled            bool    `yaml:"enabled"`
+            MinTouches         int     `yaml:"min_touches"`          // Ignore levels with fewer pivots
+            MaxDistancePercent float64 `yaml:"max_distance_percent"` // Max move away from the fixed-percentage price
+            BufferPercent      float64 `yaml:"buffer_percent"`       // Place stops beyond and targets before the level
+        } `yaml:"snap_to_levels"`
+        
+        // Reject or downsize candidates that move with the open positions
+        Correlation struct {
+            Enabled            bool    `yaml:"enabled"`
+            Timeframe          string  `yaml:"timeframe"`           // Kline interval for returns
+            Bars               int     `yaml:"bars"`                // Rolling window of returns
+            RejectAbove        float64 `yaml:"reject_above"`        // Reject at or above this correlation
+            DownsizeAbove      float64 `yaml:"downsize_above"`      // Reduce size at or above this correlation
+            DownsizeMultiplier float64 `yaml:"downsize_multiplier"` // Size multiplier when downsizing
+            WindowMinutes      float64 `yaml:"window_minutes"`      // Alerted symbols stay in the book this long
+        } `yaml:"correlation"`
+        
+        BlockRiskOff bool `yaml:"block_risk_off"` // No new positions while the market filter is risk-off
     } `yaml:"risk"`
 }
 
+// RuleConfig describes one criterion evaluated by the strategy rule engine
+type RuleConfig struct {
+    Criterion string             `yaml:"criterion"`
+    Name      string             `yaml:"name"`   // Optional label, defaults to Criterion
+    Type      string             `yaml:"type"`   // "required", "scored" or "veto"
+    Weight    float64            `yaml:"weight"` // Points added when a scored rule passes (may be negative)
+    Params    map[string]float64 `yaml:"params"`
+}
+
 type Ticker struct {
     Symbol             string
     PriceChange        float64
     PriceChangePercent float64
     LastPrice          float64
+    WeightedAvgPrice   float64 // 24h VWAP
     Volume             float64
     QuoteVolume        float64
     Timestamp          time.Time
+    
+    // Set by the universe builder
+    BaseAsset      string
+    QuoteAsset     string
+    NotionalRate   float64 // Value of one QuoteAsset in the notional asset
+    NotionalVolume float64 // QuoteVolume in the notional asset
+    RVOL           float64 // Last hour's volume vs the time-of-day baseline, 0 when not measured
+}
+
+// AggTrade is a compressed trade from /api/v3/aggTrades
+type AggTrade struct {
+    Price      float64
+    Quantity   float64
+    Time       time.Time
+    BuyerMaker bool // True when the seller was the aggressor
+}
+
+// BookLevel is one price level of the order book
+type BookLevel struct {
+    Price    float64
+    Quantity float64
+}
+
+// OrderBook is a depth snapshot, best levels first
+type OrderBook struct {
+    Bids []BookLevel
+    Asks []BookLevel
+}
+
+// AnomalyReport is the pump-and-dump / wash-trading check of a symbol
+type AnomalyReport struct {
+    Flagged bool     // Risk flag - vetoes entries
+    Flags   []string // Checks that tripped
+    
+    CandleATR         float64 // Largest recent candle body in ATRs
+    UpperWickRatio    float64 // Upper wicks / bodies over the lookback
+    TopTradeShare     float64 // Volume share of the largest trades
+    RepeatedSizeShare float64 // Share of trades repeating another trade's size
+    SpreadPercent     float64 // Best bid/ask spread (%)
+    DepthNotional     float64 // Book depth within 2% of mid, in the notional asset
+}
+
+// SymbolInfo is the exchangeInfo entry of a trading pair
+type SymbolInfo struct {
+    Symbol      string
+    Status      string // "TRADING", "BREAK", ...
+    BaseAsset   string
+    QuoteAsset  string
+    Permissions []string
+    
+    // Order filters (0 when the symbol has none)
+    StepSize    float64 // LOT_SIZE quantity increment
+    MinQty      float64
+    MaxQty      float64
+    MinNotional float64 // MIN_NOTIONAL / NOTIONAL, in the quote asset
+    MaxNotional float64
+}
+
+// MarketState summarizes the whole market once per cycle
+type MarketState struct {
+    Timestamp      time.Time
+    BTCTrend       string  // "BULLISH", "BEARISH", "NEUTRAL"
+    BTCStrength    float64 // 0-1
+    BTCChange      float64 // 24h change (%)
+    ETHTrend       string
+    ETHStrength    float64
+    ETHChange      float64
+    Advancers      int
+    Decliners      int
+    AdvanceDecline float64 // Advancers / decliners
+    AboveVWAP      float64 // Share of pairs trading above their 24h VWAP (0-1)
+    QuoteVolume    float64 // Total 24h quote volume of the pairs
+    VolumeChange   float64 // Quote volume of the last lookback vs the one before, top pairs (%)
+    RiskOff        bool
+    RiskOffReasons []string
 }
 
 type Position struct {
@@ -66,20 +345,62 @@ type Position struct {
     TrailingStopEnabled bool
     PnL                 float64
     PnLPercent          float64
+    Strategy            string  // Signal path that opened it, for per-strategy stats
+    NotionalRate        float64 // Quote asset price in the notional asset at entry
     EntryTime           time.Time
     LastUpdateTime      time.Time // NEW: Track last price update
 }
 
 type Signal struct {
     Symbol    string
-    Action    string
+    Action    string // "BUY", "SELL" or "HOLD"
     Price     float64
     Strength  float64
     Reason    string
     Timestamp time.Time
-    MTFScore  float64 // Multi-timeframe score
-    ATR       float64 // NEW: Average True Range for volatility
-    Regime    string  // NEW: Market regime (TRENDING, RANGING, VOLATILE)
+    MTFScore  float64      // Multi-timeframe score
+    ATR       float64      // NEW: Average True Range for volatility
+    Regime    string       // NEW: Market regime (TRENDING, RANGING, VOLATILE)
+    Levels    []PriceLevel // Ranked support/resistance around the entry
+    Strategy  string       // Signal path: "momentum" or "new_listing"
+    
+    QuoteAsset   string  // Asset the price is quoted in
+    NotionalRate float64 // Value of one QuoteAsset in the notional asset (sizing)
+    
+    Anomaly *AnomalyReport // Pump-and-dump / wash-trading check, when enabled
+    
+    Explanation *SignalExplanation // Structured breakdown of how the signal was scored
+}
+
+// SignalCriterion is one evaluated entry rule
+type SignalCriterion struct {
+    Name      string
+    Criterion string
+    Type      string  // "required", "scored" or "veto"
+    Value     float64 // Raw value that was tested
+    Threshold float64 // Threshold it was tested against
+    Passed    bool
+    Weight    float64 // Configured weight (scored rules)
+    Points    float64 // Points contributed to the score
+    Detail    string
+}
+
+// SignalExplanation records everything that went into a signal decision
+type SignalExplanation struct {
+    Criteria         []SignalCriterion
+    Score            float64
+    MaxScore         float64
+    Strength         float64  // Final strength compared with Threshold
+    ModelProbability *float64 // Set when a scoring model is loaded
+    Threshold        float64 // Strength required, after regime and market adjustments
+    Regime           string
+    RegimeConfidence float64
+    Rejected         bool
+    RejectReason     string
+    Timeframes       []TimeframeAnalysis
+    Patterns         []string
+    Indicators       map[string]float64 // Headline values, e.g. rsi, bb_lower, bb_upper, atr
+    Notes            []string           // Adjustments such as a risk-off threshold boost
 }
 
 type Trade struct {
@@ -100,13 +421,35 @@ type Kline struct {
     Close     float64
     Volume    float64
     CloseTime time.Time
+    
+    QuoteVolume float64
 }
 
 type TimeframeAnalysis struct {
+    Timeframe   string
+    Trend       string  // "BULLISH", "BEARISH", "NEUTRAL"
+    Strength    float64 // 0-1
+    RSI         float64
+    MACD        float64
+    Signal      float64
+    Divergences []Divergence
+}
+
+// Divergence between price and an oscillator across the last two swings
+type Divergence struct {
     Timeframe string
-    Trend     string  // "BULLISH", "BEARISH", "NEUTRAL"
-    Strength  float64 // 0-1
-    RSI       float64
-    MACD      float64
-    Signal    float64
-}
\ No newline at end of file
+    Indicator string // "RSI", "MACD", "OBV"
+    Kind      string // "REGULAR" or "HIDDEN"
+    Direction string // "BULLISH" or "BEARISH"
+    BarsAgo   int    // Bars since the most recent swing
+}
+
+// PriceLevel is a support or resistance zone built from clustered pivots
+type PriceLevel struct {
+    Price     float64
+    Kind      string    // "SUPPORT" or "RESISTANCE" relative to the current price
+    Touches   int       // Pivots merged into the level
+    LastTouch time.Time // Open time of the most recent pivot
+    BarsAgo   int       // Bars since the most recent pivot
+    Score     float64   // Ranking score (touches weighted by recency)
+}